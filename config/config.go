@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -16,6 +17,8 @@ type Config struct {
 	Postgres       PostgresConfig
 	Observability  ObservabilityConfig
 	ExternalURI    ExternalURIConfig
+	Cache          CacheConfig
+	Product        ProductConfig
 }
 
 type AppConfig struct {
@@ -39,6 +42,23 @@ type PostgresConfig struct {
 	DBName   string
 }
 
+type CacheConfig struct {
+	Enable bool
+	// The max number of the cached results
+	Size int
+	TTL  time.Duration
+}
+
+type ProductConfig struct {
+	// The soft deleted product is purged after the retention, the purge is disabled on the zero PurgeInterval or SoftDeleteRetention
+	SoftDeleteRetention time.Duration
+	PurgeInterval       time.Duration
+	// The users allowed to list the soft deleted products
+	AdminUserIds []uint64
+	// Reject the product update without the If-Match header
+	RequireIfMatch bool
+}
+
 type ObservabilityConfig struct {
 	Enable       bool
 	Mode         string
@@ -1,6 +1,6 @@
 from datetime import datetime
 from sqlalchemy.ext.declarative import declarative_base
-from sqlalchemy.dialects.postgresql import UUID
+from sqlalchemy.dialects.postgresql import UUID, ARRAY, JSONB
 from sqlalchemy.sql import func, text, expression, schema
 from sqlalchemy import (
     DateTime,
@@ -23,12 +23,61 @@ class Product(Base):
     name = Column(String, nullable=False)
     description = Column(String, nullable=False)
     price = Column(BigInteger, nullable=True)
+    # The optimistic concurrency version, incremented on every update
+    version = Column(BigInteger, nullable=False, server_default='1')
 
     created_on = Column(DateTime(timezone=True), nullable=False, server_default=func.now())
     created_by = Column(String, nullable=False)
     updated_on = Column(DateTime(timezone=True), nullable=False, server_default=func.now())
     updated_by = Column(String, nullable=False)
+    deleted_on = Column(DateTime(timezone=True), nullable=True)
+    deleted_by = Column(String, nullable=True)
 
     __table_args__  = (
         schema.Index('product_company_id_hash_index',company_id, postgresql_using='hash'),
-    )
\ No newline at end of file
+        schema.Index('product_deleted_on_index', deleted_on, postgresql_where=text('deleted_on IS NOT NULL')),
+        schema.Index(
+            'product_search_vector_gin_index',
+            text(
+                "(setweight(to_tsvector('simple', coalesce(name, '')), 'A') || "
+                "setweight(to_tsvector('simple', coalesce(description, '')), 'B') || "
+                "setweight(to_tsvector('simple', coalesce(price::text, '')), 'D'))"
+            ),
+            postgresql_using='gin',
+        ),
+    )
+
+
+class ProductView(Base):
+    __tablename__ = 'product_view'
+
+    company_id = Column(BigInteger, nullable=False)
+    uuid = Column(UUID(as_uuid=True), primary_key=True)
+    name = Column(String, nullable=False)
+    filters = Column(ARRAY(String), nullable=False, server_default='{}')
+    sorts = Column(ARRAY(String), nullable=False, server_default='{}')
+
+    created_on = Column(DateTime(timezone=True), nullable=False, server_default=func.now())
+    created_by = Column(String, nullable=False)
+
+    __table_args__  = (
+        schema.UniqueConstraint(company_id, name, name='product_view_company_id_name_unique'),
+    )
+
+
+# The product history is kept after the product is purged
+class ProductHistory(Base):
+    __tablename__ = 'product_history'
+
+    company_id = Column(BigInteger, nullable=False)
+    uuid = Column(UUID(as_uuid=True), primary_key=True)
+    product_uuid = Column(UUID(as_uuid=True), nullable=False)
+    operation = Column(String, nullable=False)
+    changes = Column(JSONB, nullable=False, server_default='{}')
+
+    created_on = Column(DateTime(timezone=True), nullable=False, server_default=func.now())
+    created_by = Column(String, nullable=False)
+
+    __table_args__  = (
+        schema.Index('product_history_product_uuid_created_on_index', product_uuid, created_on),
+    )
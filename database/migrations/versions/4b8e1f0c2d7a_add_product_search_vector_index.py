@@ -0,0 +1,32 @@
+"""add_product_search_vector_index
+
+Revision ID: 4b8e1f0c2d7a
+Revises: 7bcca5a2905d
+Create Date: 2026-10-18 09:12:40.318554
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '4b8e1f0c2d7a'
+down_revision = '7bcca5a2905d'
+branch_labels = None
+depends_on = None
+
+
+# Must be the same expression as the resourceful full-text search vector of the product definition
+SEARCH_VECTOR = (
+    "setweight(to_tsvector('simple', coalesce(name, '')), 'A') || "
+    "setweight(to_tsvector('simple', coalesce(description, '')), 'B') || "
+    "setweight(to_tsvector('simple', coalesce(price::text, '')), 'D')"
+)
+
+
+def upgrade() -> None:
+    op.create_index('product_search_vector_gin_index', 'product', [sa.text(f"({SEARCH_VECTOR})")], unique=False, postgresql_using='gin')
+
+
+def downgrade() -> None:
+    op.drop_index('product_search_vector_gin_index', table_name='product', postgresql_using='gin')
@@ -0,0 +1,38 @@
+"""create_product_view_table
+
+Revision ID: 9d2c6a41e5b3
+Revises: 4b8e1f0c2d7a
+Create Date: 2026-10-18 10:05:21.447913
+
+"""
+from alembic import op
+import sqlalchemy as sa
+from sqlalchemy.dialects import postgresql
+
+# revision identifiers, used by Alembic.
+revision = '9d2c6a41e5b3'
+down_revision = '4b8e1f0c2d7a'
+branch_labels = None
+depends_on = None
+
+
+def upgrade() -> None:
+    # ### commands auto generated by Alembic - please adjust! ###
+    op.create_table('product_view',
+    sa.Column('company_id', sa.BigInteger(), nullable=False),
+    sa.Column('uuid', postgresql.UUID(as_uuid=True), nullable=False),
+    sa.Column('name', sa.String(), nullable=False),
+    sa.Column('filters', postgresql.ARRAY(sa.String()), server_default='{}', nullable=False),
+    sa.Column('sorts', postgresql.ARRAY(sa.String()), server_default='{}', nullable=False),
+    sa.Column('created_on', sa.DateTime(timezone=True), server_default=sa.text('now()'), nullable=False),
+    sa.Column('created_by', sa.String(), nullable=False),
+    sa.PrimaryKeyConstraint('uuid'),
+    sa.UniqueConstraint('company_id', 'name', name='product_view_company_id_name_unique')
+    )
+    # ### end Alembic commands ###
+
+
+def downgrade() -> None:
+    # ### commands auto generated by Alembic - please adjust! ###
+    op.drop_table('product_view')
+    # ### end Alembic commands ###
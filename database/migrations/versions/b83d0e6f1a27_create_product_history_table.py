@@ -0,0 +1,39 @@
+"""create_product_history_table
+
+Revision ID: b83d0e6f1a27
+Revises: e5f2a7c91b04
+Create Date: 2026-10-18 15:20:37.604192
+
+"""
+from alembic import op
+import sqlalchemy as sa
+from sqlalchemy.dialects import postgresql
+
+# revision identifiers, used by Alembic.
+revision = 'b83d0e6f1a27'
+down_revision = 'e5f2a7c91b04'
+branch_labels = None
+depends_on = None
+
+
+def upgrade() -> None:
+    # ### commands auto generated by Alembic - please adjust! ###
+    op.create_table('product_history',
+    sa.Column('company_id', sa.BigInteger(), nullable=False),
+    sa.Column('uuid', postgresql.UUID(as_uuid=True), nullable=False),
+    sa.Column('product_uuid', postgresql.UUID(as_uuid=True), nullable=False),
+    sa.Column('operation', sa.String(), nullable=False),
+    sa.Column('changes', postgresql.JSONB(astext_type=sa.Text()), server_default='{}', nullable=False),
+    sa.Column('created_on', sa.DateTime(timezone=True), server_default=sa.text('now()'), nullable=False),
+    sa.Column('created_by', sa.String(), nullable=False),
+    sa.PrimaryKeyConstraint('uuid')
+    )
+    op.create_index('product_history_product_uuid_created_on_index', 'product_history', ['product_uuid', 'created_on'], unique=False)
+    # ### end Alembic commands ###
+
+
+def downgrade() -> None:
+    # ### commands auto generated by Alembic - please adjust! ###
+    op.drop_index('product_history_product_uuid_created_on_index', table_name='product_history')
+    op.drop_table('product_history')
+    # ### end Alembic commands ###
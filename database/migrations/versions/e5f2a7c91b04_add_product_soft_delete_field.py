@@ -0,0 +1,32 @@
+"""add_product_soft_delete_field
+
+Revision ID: e5f2a7c91b04
+Revises: 9d2c6a41e5b3
+Create Date: 2026-10-18 13:42:08.215734
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = 'e5f2a7c91b04'
+down_revision = '9d2c6a41e5b3'
+branch_labels = None
+depends_on = None
+
+
+def upgrade() -> None:
+    # ### commands auto generated by Alembic - please adjust! ###
+    op.add_column('product', sa.Column('deleted_on', sa.DateTime(timezone=True), nullable=True))
+    op.add_column('product', sa.Column('deleted_by', sa.String(), nullable=True))
+    op.create_index('product_deleted_on_index', 'product', ['deleted_on'], unique=False, postgresql_where=sa.text('deleted_on IS NOT NULL'))
+    # ### end Alembic commands ###
+
+
+def downgrade() -> None:
+    # ### commands auto generated by Alembic - please adjust! ###
+    op.drop_index('product_deleted_on_index', table_name='product', postgresql_where=sa.text('deleted_on IS NOT NULL'))
+    op.drop_column('product', 'deleted_by')
+    op.drop_column('product', 'deleted_on')
+    # ### end Alembic commands ###
@@ -0,0 +1,28 @@
+"""add_product_version_field
+
+Revision ID: f41c8b2d6e93
+Revises: b83d0e6f1a27
+Create Date: 2026-10-18 17:03:51.918406
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = 'f41c8b2d6e93'
+down_revision = 'b83d0e6f1a27'
+branch_labels = None
+depends_on = None
+
+
+def upgrade() -> None:
+    # ### commands auto generated by Alembic - please adjust! ###
+    op.add_column('product', sa.Column('version', sa.BigInteger(), server_default='1', nullable=False))
+    # ### end Alembic commands ###
+
+
+def downgrade() -> None:
+    # ### commands auto generated by Alembic - please adjust! ###
+    op.drop_column('product', 'version')
+    # ### end Alembic commands ###
@@ -22,6 +22,7 @@ func CORSMiddleware(appConfig config.AppConfig) func(*fiber.Ctx) error {
 		AllowOrigins:     strings.Join(allowedOrigins, ","),
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH",
 		AllowCredentials: true,
-		AllowHeaders:     "Authorization,Content-Type,Traceparent",
+		AllowHeaders:     "Authorization,Content-Type,Traceparent,If-Match",
+		ExposeHeaders:    "ETag",
 	})
 }
@@ -4,8 +4,17 @@ import "github.com/gofiber/fiber/v2"
 
 type Handlers interface {
 	Index(c *fiber.Ctx) error
+	Stats(c *fiber.Ctx) error
+	Export(c *fiber.Ctx) error
+	Meta(c *fiber.Ctx) error
 	Store(c *fiber.Ctx) error
 	Show(c *fiber.Ctx) error
 	Update(c *fiber.Ctx) error
 	Delete(c *fiber.Ctx) error
+	Restore(c *fiber.Ctx) error
+	History(c *fiber.Ctx) error
+
+	IndexViews(c *fiber.Ctx) error
+	StoreView(c *fiber.Ctx) error
+	DeleteView(c *fiber.Ctx) error
 }
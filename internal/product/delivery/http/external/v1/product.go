@@ -1,14 +1,21 @@
 package v1
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
 	"mceasy/service-demo/config"
 	"mceasy/service-demo/internal/identity/identityentities"
 	"mceasy/service-demo/internal/product"
 	"mceasy/service-demo/internal/product/dtos"
 	"mceasy/service-demo/internal/product/entities"
+	"mceasy/service-demo/pkg/apperror"
 	"mceasy/service-demo/pkg/observability/instrumentation"
 	"mceasy/service-demo/pkg/resourceful"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -50,11 +57,34 @@ func (h *productHandler) Index(c *fiber.Ctx) error {
 	resource := resourceful.NewResource[uuid.UUID, dtos.ProductList](ProductDefinition)
 
 	resource.Parameter = &resourceful.Parameter{
-		Limit:   request.Limit,
-		Page:    request.Page,
-		Search:  request.Search,
-		Filters: request.Filters,
-		Sorts:   request.Sorts,
+		Pagination: request.Pagination,
+		Limit:      request.Limit,
+		Page:       request.Page,
+		Cursor:     request.Cursor,
+		Search:     request.Search,
+		Filters:    request.Filters,
+		Sorts:      request.Sorts,
+		Fields:     request.Fields,
+		Facets:     request.Facets,
+		Includes:   request.Include,
+		View:       request.View,
+	}
+
+	// The soft deleted products are only listed for the admin
+	if request.IncludeDeleted || request.OnlyDeleted {
+		if !h.isAdmin(authCredential) {
+			return apperror.Forbidden()
+		}
+
+		softDeleteMode := resourceful.INCLUDE_DELETED
+		if request.OnlyDeleted {
+			softDeleteMode = resourceful.ONLY_DELETED
+		}
+
+		err = resource.SetSoftDeleteMode(softDeleteMode)
+		if err != nil {
+			return err
+		}
 	}
 
 	resourceProduct, err := h.productUC.Index(ctx, authCredential.CompanyId, resource)
@@ -72,6 +102,211 @@ func (h *productHandler) Index(c *fiber.Ctx) error {
 	// return c.JSON(products)
 }
 
+func (h *productHandler) Stats(c *fiber.Ctx) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		c.UserContext(),
+		"StatsHandler",
+	)
+	defer span.End()
+
+	var request dtos.StatsRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return err
+	}
+
+	authCredential := c.Locals(identityentities.KeyAuthCredential).(identityentities.Credential)
+
+	resource := resourceful.NewResource[uuid.UUID, dtos.ProductList](ProductDefinition)
+
+	resource.Parameter = &resourceful.Parameter{
+		Search:  request.Search,
+		Filters: request.Filters,
+	}
+
+	productStats, err := h.productUC.Stats(ctx, authCredential.CompanyId, resource)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: productStats})
+}
+
+func (h *productHandler) Export(c *fiber.Ctx) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		c.UserContext(),
+		"ExportHandler",
+	)
+	defer span.End()
+
+	var request dtos.ExportRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return err
+	}
+
+	err = request.Mod().Validate()
+	if err != nil {
+		return err
+	}
+
+	authCredential := c.Locals(identityentities.KeyAuthCredential).(identityentities.Credential)
+
+	resource := resourceful.NewResource[uuid.UUID, dtos.ProductList](ProductDefinition)
+
+	resource.Parameter = &resourceful.Parameter{
+		Search:  request.Search,
+		Filters: request.Filters,
+		Sorts:   request.Sorts,
+		Fields:  request.Fields,
+		View:    request.View,
+	}
+
+	stream, err := h.productUC.Export(ctx, authCredential.CompanyId, resource, request.Format)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, dtos.ExportContentTypes[request.Format])
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="product.%s"`, request.Format))
+
+	// The rows are streamed after the handler returns, the stream has its own span & the error is traced
+	// since the response has already started
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		streamCtx, streamSpan := instrumentation.NewTraceSpan(
+			streamCtx,
+			"ExportStreamHandler",
+		)
+		defer streamSpan.End()
+
+		// The failed write is the gone client connection, the export query is canceled
+		err := stream(streamCtx, &cancelWriter{writer: w, cancel: cancel})
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			instrumentation.RecordSpanError(streamSpan, err)
+		}
+	})
+
+	return nil
+}
+
+// cancelWriter cancels the context on the first failed write
+type cancelWriter struct {
+	writer io.Writer
+	cancel context.CancelFunc
+}
+
+func (w *cancelWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	if err != nil {
+		w.cancel()
+	}
+
+	return n, err
+}
+
+// Describe the product definition & the OpenAPI parameters of the Index endpoint
+func (h *productHandler) Meta(c *fiber.Ctx) error {
+	_, span := instrumentation.NewTraceSpan(
+		c.UserContext(),
+		"MetaHandler",
+	)
+	defer span.End()
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: dtos.MetaResponse{
+		Definition: ProductDefinition.Metadata(),
+		Parameters: ProductDefinition.OpenAPIParameters(),
+	}})
+}
+
+func (h *productHandler) IndexViews(c *fiber.Ctx) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		c.UserContext(),
+		"IndexViewsHandler",
+	)
+	defer span.End()
+
+	companyId := c.Locals(
+		identityentities.KeyAuthCredential,
+	).(identityentities.Credential).CompanyId
+
+	productViews, err := h.productUC.IndexViews(ctx, int64(companyId))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(entities.ResponseData{Data: productViews})
+}
+
+func (h *productHandler) StoreView(c *fiber.Ctx) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		c.UserContext(),
+		"StoreViewHandler",
+	)
+	defer span.End()
+
+	var createProductView dtos.CreateProductViewRequest
+	err := c.BodyParser(&createProductView)
+	if err != nil {
+		return err
+	}
+
+	err = createProductView.Mod().Validate()
+	if err != nil {
+		return err
+	}
+
+	productViewId, err := h.productUC.StoreView(
+		ctx,
+		c.Locals(identityentities.KeyAuthCredential).(identityentities.Credential),
+		entities.StoreProductView{
+			Name:    createProductView.Name,
+			Filters: createProductView.Filters,
+			Sorts:   createProductView.Sorts,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(
+		entities.ResponseData{Data: map[string]any{"id": productViewId}},
+	)
+}
+
+func (h *productHandler) DeleteView(c *fiber.Ctx) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		c.UserContext(),
+		"DeleteViewHandler",
+	)
+	defer span.End()
+
+	var param struct {
+		ProductViewUUID uuid.UUID `params:"productViewId"`
+	}
+
+	err := c.ParamsParser(&param)
+	if err != nil {
+		return err
+	}
+
+	companyId := c.Locals(
+		identityentities.KeyAuthCredential,
+	).(identityentities.Credential).CompanyId
+
+	err = h.productUC.DeleteView(ctx, param.ProductViewUUID.String(), int64(companyId))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
 func (h *productHandler) Store(c *fiber.Ctx) error {
 	ctx, span := instrumentation.NewTraceSpan(
 		c.UserContext(),
@@ -138,6 +373,9 @@ func (h *productHandler) Show(c *fiber.Ctx) error {
 		return err
 	}
 
+	// The ETag is sent back by the If-Match of the update
+	c.Set(fiber.HeaderETag, productETag(product.Version))
+
 	return c.Status(http.StatusOK).JSON(entities.ResponseData{
 		Data: dtos.NewProductResponse(product),
 	})
@@ -159,11 +397,11 @@ func (h *productHandler) Delete(c *fiber.Ctx) error {
 		return err
 	}
 
-	companyId := c.Locals(
-		identityentities.KeyAuthCredential,
-	).(identityentities.Credential).CompanyId
-
-	err = h.productUC.Delete(ctx, param.ProductUUID.String(), int64(companyId))
+	err = h.productUC.Delete(
+		ctx,
+		c.Locals(identityentities.KeyAuthCredential).(identityentities.Credential),
+		param.ProductUUID.String(),
+	)
 	if err != nil {
 		return err
 	}
@@ -172,6 +410,34 @@ func (h *productHandler) Delete(c *fiber.Ctx) error {
 
 }
 
+func (h *productHandler) Restore(c *fiber.Ctx) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		c.UserContext(),
+		"RestoreHandler",
+	)
+	defer span.End()
+
+	var param struct {
+		ProductUUID uuid.UUID `params:"productId"`
+	}
+
+	err := c.ParamsParser(&param)
+	if err != nil {
+		return err
+	}
+
+	err = h.productUC.Restore(
+		ctx,
+		c.Locals(identityentities.KeyAuthCredential).(identityentities.Credential),
+		param.ProductUUID.String(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
+
 func (h *productHandler) Update(c *fiber.Ctx) error {
 	ctx, span := instrumentation.NewTraceSpan(
 		c.UserContext(),
@@ -199,11 +465,16 @@ func (h *productHandler) Update(c *fiber.Ctx) error {
 		return err
 	}
 
+	version, err := h.ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
 	requestCredential := c.Locals(
 		identityentities.KeyAuthCredential,
 	).(identityentities.Credential)
 
-	err = h.productUC.Update(
+	newVersion, err := h.productUC.Update(
 		ctx,
 		requestCredential,
 		param.ProductUUID.String(),
@@ -215,8 +486,110 @@ func (h *productHandler) Update(c *fiber.Ctx) error {
 			Price:       updateProduct.Price,
 			UpdatedOn:   time.Now(),
 			UpdatedBy:   requestCredential.UserName,
+			Version:     version,
 		},
 	)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	c.Set(fiber.HeaderETag, productETag(newVersion))
+
+	return c.SendStatus(http.StatusOK)
+}
+
+func (h *productHandler) History(c *fiber.Ctx) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		c.UserContext(),
+		"HistoryHandler",
+	)
+	defer span.End()
+
+	var param struct {
+		ProductUUID uuid.UUID `params:"productId"`
+	}
+
+	err := c.ParamsParser(&param)
+	if err != nil {
+		return err
+	}
+
+	var request dtos.HistoryRequest
+	err = c.QueryParser(&request)
+	if err != nil {
+		return err
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	companyId := c.Locals(
+		identityentities.KeyAuthCredential,
+	).(identityentities.Credential).CompanyId
+
+	resource := resourceful.NewResource[uuid.UUID, dtos.ProductHistoryList](ProductHistoryDefinition)
+
+	resource.Parameter = &resourceful.Parameter{
+		Pagination: request.Pagination,
+		Limit:      request.Limit,
+		Page:       request.Page,
+		Cursor:     request.Cursor,
+		Search:     request.Search,
+		Filters:    request.Filters,
+		Sorts:      request.Sorts,
+		Facets:     request.Facets,
+	}
+
+	historyResource, err := h.productUC.History(ctx, companyId, param.ProductUUID.String(), resource)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(historyResource.Response())
+}
+
+func (h *productHandler) isAdmin(credential identityentities.Credential) bool {
+	for _, adminUserId := range h.config.Product.AdminUserIds {
+		if adminUserId == credential.UserId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func productETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// Get the expected product version of the If-Match header, the zero version is any version
+// (the "*" or the optional missing header). The weak ETag never matches on the strong comparison of If-Match
+func (h *productHandler) ifMatchVersion(c *fiber.Ctx) (int64, error) {
+	ifMatch := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if ifMatch == "" {
+		if h.config.Product.RequireIfMatch {
+			return 0, apperror.BadRequestMap(map[string][]string{
+				"if_match": {"is required"},
+			})
+		}
+		return 0, nil
+	}
+	if ifMatch == "*" {
+		return 0, nil
+	}
+
+	if strings.HasPrefix(ifMatch, "W/") {
+		return 0, apperror.PreconditionFailed()
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(ifMatch, `"`), 10, 64)
+	if err != nil || version < 1 {
+		return 0, apperror.BadRequestMap(map[string][]string{
+			"if_match": {"must be the product ETag"},
+		})
+	}
+
+	return version, nil
 }
@@ -1,14 +1,18 @@
 package v1_test
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
 	"mceasy/service-demo/config"
 	"mceasy/service-demo/internal/identity/identityentities"
 	v1 "mceasy/service-demo/internal/product/delivery/http/external/v1"
 	"mceasy/service-demo/internal/product/dtos"
 	"mceasy/service-demo/internal/product/entities"
 	mocks "mceasy/service-demo/internal/product/mock"
+	"mceasy/service-demo/internal/product/tabledefinition"
 	"mceasy/service-demo/pkg/apperror"
 	"mceasy/service-demo/pkg/resourceful"
 	"net/http"
@@ -61,6 +65,7 @@ func TestProductHandler_Show(t *testing.T) {
 			Name:        "Mie Sedap !",
 			Description: "Sedap sekali",
 			Price:       2000,
+			Version:     4,
 		}
 
 		mockProductUC.
@@ -85,6 +90,7 @@ func TestProductHandler_Show(t *testing.T) {
 		resp, err := app.Test(req)
 		require.NoError(t, err)
 		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, `"4"`, resp.Header.Get("ETag"))
 
 		var contract struct {
 			Data struct {
@@ -133,10 +139,11 @@ func TestProductHandler_Index(t *testing.T) {
 			productUUID := uuid.New()
 			ids = append(ids, productUUID)
 
+			name, description := faker.WORD, faker.WORD
 			products = append(products, dtos.ProductList{
 				UUID:        productUUID.String(),
-				Name:        faker.WORD,
-				Description: faker.WORD,
+				Name:        &name,
+				Description: &description,
 			})
 		}
 
@@ -195,6 +202,473 @@ func TestProductHandler_Index(t *testing.T) {
 		assert.NotEmpty(t, contract.Data.PaginatedResult[0].Name)
 		assert.NotEmpty(t, contract.Data.PaginatedResult[0].Description)
 	})
+
+	t.Run("contract_test_cursor_pagination", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queryParameter := "?limit=10&pagination=cursor&sorts=name+desc"
+
+		resourceParam := resourceful.Parameter{
+			Pagination: resourceful.CURSOR_PAGINATION,
+			Limit:      10,
+			Sorts:      []string{"name desc"},
+		}
+
+		expectedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		expectedResource.Parameter = &resourceParam
+
+		returnedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		err := returnedResource.SetParam(resourceParam)
+		require.NoError(t, err)
+
+		productUUID := uuid.New()
+		name, description := faker.WORD, faker.WORD
+		returnedResource.SetResult(resourceful.Result[uuid.UUID, dtos.ProductList]{
+			Ids: []uuid.UUID{productUUID},
+			PaginatedResult: []dtos.ProductList{
+				{UUID: productUUID.String(), Name: &name, Description: &description},
+			},
+		})
+
+		// Call mock usecase
+		productUCMock := mocks.NewMockUseCase(ctrl)
+		productUCMock.EXPECT().Index(gomock.Any(), uint64(392), expectedResource).Return(returnedResource, nil)
+
+		// Action
+		productHandler := v1.NewProductHandler(config.Config{}, productUCMock)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/product%s", queryParameter), nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		var contract struct {
+			Metadata struct {
+				Count      int    `json:"count"`
+				HasNext    bool   `json:"has_next"`
+				NextCursor string `json:"next_cursor"`
+			} `json:"metadata"`
+			Data struct {
+				PaginatedResult []struct {
+					Id          *string `json:"id"`
+					Name        *string `json:"name"`
+					Description *string `json:"description"`
+				} `json:"paginated_result"`
+				Ids []string `json:"ids"`
+			} `json:"data"`
+		}
+
+		jsonDecoder := json.NewDecoder(response.Body)
+		jsonDecoder.DisallowUnknownFields()
+		err = jsonDecoder.Decode(&contract)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, contract.Metadata.Count)
+		assert.False(t, contract.Metadata.HasNext)
+		assert.Empty(t, contract.Metadata.NextCursor)
+		assert.NotEmpty(t, contract.Data.PaginatedResult[0].Id)
+	})
+
+	t.Run("contract_test_fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queryParameter := "?limit=10&page=1&fields=name,price"
+
+		resourceParam := resourceful.Parameter{
+			Limit:  10,
+			Page:   1,
+			Fields: []string{"name", "price"},
+		}
+
+		expectedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		expectedResource.Parameter = &resourceParam
+
+		returnedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		err := returnedResource.SetParam(resourceParam)
+		require.NoError(t, err)
+
+		productUUID := uuid.New()
+		name, price := faker.WORD, int64(2000)
+		returnedResource.SetResult(resourceful.Result[uuid.UUID, dtos.ProductList]{
+			Ids: []uuid.UUID{productUUID},
+			PaginatedResult: []dtos.ProductList{
+				{UUID: productUUID.String(), Name: &name, Price: &price},
+			},
+		})
+
+		// Call mock usecase
+		productUCMock := mocks.NewMockUseCase(ctrl)
+		productUCMock.EXPECT().Index(gomock.Any(), uint64(392), expectedResource).Return(returnedResource, nil)
+
+		// Action
+		productHandler := v1.NewProductHandler(config.Config{}, productUCMock)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/product%s", queryParameter), nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		var contract struct {
+			Metadata struct {
+				Count      int `json:"count"`
+				Page       int `json:"page"`
+				TotalPage  int `json:"total_page"`
+				TotalCount int `json:"total_count"`
+			} `json:"metadata"`
+			Data struct {
+				PaginatedResult []struct {
+					Id    *string `json:"id"`
+					Name  *string `json:"name"`
+					Price *int64  `json:"price"`
+				} `json:"paginated_result"`
+				Ids []string `json:"ids"`
+			} `json:"data"`
+		}
+
+		jsonDecoder := json.NewDecoder(response.Body)
+		jsonDecoder.DisallowUnknownFields()
+		err = jsonDecoder.Decode(&contract)
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, contract.Data.PaginatedResult[0].Id)
+		assert.Equal(t, name, *contract.Data.PaginatedResult[0].Name)
+		assert.Equal(t, price, *contract.Data.PaginatedResult[0].Price)
+	})
+
+	t.Run("contract_test_facets", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queryParameter := "?limit=10&page=1&facets=created_by"
+
+		resourceParam := resourceful.Parameter{
+			Limit:  10,
+			Page:   1,
+			Facets: []string{"created_by"},
+		}
+
+		expectedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		expectedResource.Parameter = &resourceParam
+
+		returnedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		err := returnedResource.SetParam(resourceParam)
+		require.NoError(t, err)
+
+		err = returnedResource.ScanFacetRows(tabledefinition.Product.Field("created_by"), &facetRows{rows: [][2]any{
+			{"admin", int64(3)},
+			{"cavalry", int64(1)},
+		}})
+		require.NoError(t, err)
+		returnedResource.SetResult(resourceful.Result[uuid.UUID, dtos.ProductList]{})
+
+		// Call mock usecase
+		productUCMock := mocks.NewMockUseCase(ctrl)
+		productUCMock.EXPECT().Index(gomock.Any(), uint64(392), expectedResource).Return(returnedResource, nil)
+
+		// Action
+		productHandler := v1.NewProductHandler(config.Config{}, productUCMock)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/product%s", queryParameter), nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		var contract struct {
+			Metadata struct {
+				Count      int `json:"count"`
+				Page       int `json:"page"`
+				TotalPage  int `json:"total_page"`
+				TotalCount int `json:"total_count"`
+			} `json:"metadata"`
+			Data struct {
+				PaginatedResult []dtos.ProductList `json:"paginated_result"`
+				Ids             []string           `json:"ids"`
+			} `json:"data"`
+			Facets map[string][]struct {
+				Value string `json:"value"`
+				Count int64  `json:"count"`
+			} `json:"facets"`
+		}
+
+		jsonDecoder := json.NewDecoder(response.Body)
+		jsonDecoder.DisallowUnknownFields()
+		err = jsonDecoder.Decode(&contract)
+		require.NoError(t, err)
+
+		require.Len(t, contract.Facets["created_by"], 2)
+		assert.Equal(t, "admin", contract.Facets["created_by"][0].Value)
+		assert.Equal(t, int64(3), contract.Facets["created_by"][0].Count)
+	})
+
+	t.Run("only_deleted_for_admin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		resourceParam := resourceful.Parameter{Limit: 10, Page: 1}
+
+		expectedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		expectedResource.Parameter = &resourceParam
+		err := expectedResource.SetSoftDeleteMode(resourceful.ONLY_DELETED)
+		require.NoError(t, err)
+
+		returnedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		err = returnedResource.SetParam(resourceParam)
+		require.NoError(t, err)
+		returnedResource.SetResult(resourceful.Result[uuid.UUID, dtos.ProductList]{})
+
+		productUCMock := mocks.NewMockUseCase(ctrl)
+		productUCMock.EXPECT().Index(gomock.Any(), uint64(392), expectedResource).Return(returnedResource, nil)
+
+		productHandler := v1.NewProductHandler(config.Config{Product: config.ProductConfig{AdminUserIds: []uint64{1}}}, productUCMock)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/product?limit=10&page=1&only_deleted=true", nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("forbidden_include_deleted_for_non_admin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productHandler := v1.NewProductHandler(config.Config{Product: config.ProductConfig{AdminUserIds: []uint64{2}}}, mocks.NewMockUseCase(ctrl))
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/product?limit=10&page=1&include_deleted=true", nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, response.StatusCode)
+	})
+
+	t.Run("bad_request_include_and_only_deleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productHandler := v1.NewProductHandler(config.Config{Product: config.ProductConfig{AdminUserIds: []uint64{1}}}, mocks.NewMockUseCase(ctrl))
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/product?limit=10&page=1&include_deleted=true&only_deleted=true", nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
+type facetRows struct {
+	rows  [][2]any
+	index int
+}
+
+func (f *facetRows) Next() bool {
+	f.index++
+	return f.index <= len(f.rows)
+}
+
+func (f *facetRows) Scan(dest ...any) error {
+	err := dest[0].(*sql.NullString).Scan(f.rows[f.index-1][0])
+	if err != nil {
+		return err
+	}
+	*dest[1].(*int64) = f.rows[f.index-1][1].(int64)
+
+	return nil
+}
+
+func (f *facetRows) Err() error {
+	return nil
+}
+
+func TestProductHandler_Stats(t *testing.T) {
+	t.Run("contract_test", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queryParameter := "?search=kacang&filters=name%20contains%20goreng"
+
+		expectedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		expectedResource.Parameter = &resourceful.Parameter{
+			Search:  "kacang",
+			Filters: []string{"name contains goreng"},
+		}
+
+		returnedStats := dtos.ProductStats{Count: 2, TotalPrice: 3000, AveragePrice: 1500, MinPrice: 1000, MaxPrice: 2000}
+
+		productUCMock := mocks.NewMockUseCase(ctrl)
+		productUCMock.EXPECT().Stats(gomock.Any(), uint64(392), expectedResource).Return(returnedStats, nil)
+
+		productHandler := v1.NewProductHandler(config.Config{}, productUCMock)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/product/stats%s", queryParameter), nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		var contract struct {
+			Data struct {
+				Count        int64   `json:"count"`
+				TotalPrice   float64 `json:"total_price"`
+				AveragePrice float64 `json:"average_price"`
+				MinPrice     float64 `json:"min_price"`
+				MaxPrice     float64 `json:"max_price"`
+			} `json:"data"`
+		}
+
+		jsonDecoder := json.NewDecoder(response.Body)
+		jsonDecoder.DisallowUnknownFields()
+		err = jsonDecoder.Decode(&contract)
+		require.NoError(t, err)
+
+		assert.Equal(t, returnedStats.Count, contract.Data.Count)
+		assert.Equal(t, returnedStats.AveragePrice, contract.Data.AveragePrice)
+		assert.Equal(t, returnedStats.MaxPrice, contract.Data.MaxPrice)
+	})
+}
+
+func TestProductHandler_Export(t *testing.T) {
+	t.Run("contract_test", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queryParameter := "?search=kacang&fields=name&fields=price"
+
+		expectedResource := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		expectedResource.Parameter = &resourceful.Parameter{
+			Search: "kacang",
+			Fields: []string{"name", "price"},
+		}
+
+		productUCMock := mocks.NewMockUseCase(ctrl)
+		productUCMock.EXPECT().Export(gomock.Any(), uint64(392), expectedResource, resourceful.CSV_EXPORT).Return(func(ctx context.Context, w io.Writer) error {
+			require.NoError(t, ctx.Err())
+			_, err := io.WriteString(w, "uuid,name,price\n")
+			return err
+		}, nil)
+
+		productHandler := v1.NewProductHandler(config.Config{}, productUCMock)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/product/export%s", queryParameter), nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "text/csv", response.Header.Get(fiber.HeaderContentType))
+		assert.Equal(t, `attachment; filename="product.csv"`, response.Header.Get(fiber.HeaderContentDisposition))
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "uuid,name,price\n", string(body))
+	})
+
+	t.Run("invalid_format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productHandler := v1.NewProductHandler(config.Config{}, mocks.NewMockUseCase(ctrl))
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/product/export?format=pdf", nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
+func TestProductHandler_Meta(t *testing.T) {
+	t.Run("contract_test", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productHandler := v1.NewProductHandler(config.Config{}, mocks.NewMockUseCase(ctrl))
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/product/_meta", nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		var contract struct {
+			Data struct {
+				Definition struct {
+					SearchStrategy string   `json:"search_strategy"`
+					SearchFields   []string `json:"search_fields"`
+					DefaultSorts   []string `json:"default_sorts"`
+					Fields         []struct {
+						Key         string    `json:"key"`
+						Type        string    `json:"type"`
+						Filterable  bool      `json:"filterable"`
+						Sortable    bool      `json:"sortable"`
+						Selectable  bool      `json:"selectable"`
+						Facetable   bool      `json:"facetable"`
+						FacetRanges []float64 `json:"facet_ranges"`
+						Searchable  bool      `json:"searchable"`
+						Operators   []string  `json:"operators"`
+					} `json:"fields"`
+					Includes []string `json:"includes"`
+					Views    []struct {
+						Name    string   `json:"name"`
+						Filters []string `json:"filters"`
+						Sorts   []string `json:"sorts"`
+					} `json:"views"`
+				} `json:"definition"`
+				Parameters []struct {
+					Name        string `json:"name"`
+					In          string `json:"in"`
+					Description string `json:"description"`
+					Schema      struct {
+						Type      string   `json:"type"`
+						Enum      []string `json:"enum"`
+						Minimum   int      `json:"minimum"`
+						Maximum   int      `json:"maximum"`
+						MinLength int      `json:"minLength"`
+						MaxItems  int      `json:"maxItems"`
+						Default   string   `json:"default"`
+						Items     struct {
+							Type string   `json:"type"`
+							Enum []string `json:"enum"`
+						} `json:"items"`
+					} `json:"schema"`
+				} `json:"parameters"`
+			} `json:"data"`
+		}
+
+		jsonDecoder := json.NewDecoder(response.Body)
+		jsonDecoder.DisallowUnknownFields()
+		err = jsonDecoder.Decode(&contract)
+		require.NoError(t, err)
+
+		assert.Equal(t, resourceful.FULLTEXT_SEARCH, contract.Data.Definition.SearchStrategy)
+		assert.Equal(t, []string{"name", "description", "price"}, contract.Data.Definition.SearchFields)
+		assert.Equal(t, "recently_updated_expensive", contract.Data.Definition.Views[0].Name)
+		for _, field := range contract.Data.Definition.Fields {
+			assert.NotEqual(t, "company_id", field.Key)
+			if field.Key == "price" {
+				assert.Equal(t, tabledefinition.ProductPriceBands, field.FacetRanges)
+			}
+		}
+		assert.NotEmpty(t, contract.Data.Parameters)
+		for _, parameter := range contract.Data.Parameters {
+			if parameter.Name == "limit" {
+				assert.Equal(t, tabledefinition.ProductPolicy.MaxLimit, parameter.Schema.Maximum)
+			}
+		}
+	})
 }
 
 func TestProductHandler_Store(t *testing.T) {
@@ -257,8 +731,8 @@ func TestProductHandler_Delete(t *testing.T) {
 
 		productUCMock.EXPECT().Delete(
 			gomock.Any(),
+			identityentities.Credential{UserName: "cavalry", UserId: 1, CompanyId: 392},
 			productUUID,
-			int64(392),
 		).Return(nil)
 
 		reasonHandler := v1.NewProductHandler(config.Config{}, productUCMock)
@@ -279,6 +753,247 @@ func TestProductHandler_Delete(t *testing.T) {
 	})
 }
 
+func TestProductHandler_Restore(t *testing.T) {
+	t.Run("Ok_when_valid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productUUID := uuid.NewString()
+
+		productUCMock := mocks.NewMockUseCase(ctrl)
+
+		productUCMock.EXPECT().Restore(
+			gomock.Any(),
+			identityentities.Credential{UserName: "cavalry", UserId: 1, CompanyId: 392},
+			productUUID,
+		).Return(nil)
+
+		productHandler := v1.NewProductHandler(config.Config{}, productUCMock)
+
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		req := httptest.NewRequest(
+			http.MethodPost,
+			fmt.Sprintf("/product/%s/restore", productUUID),
+			nil,
+		)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestProductHandler_History(t *testing.T) {
+	t.Run("contract_test", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productUUID := uuid.NewString()
+
+		resourceParam := resourceful.Parameter{
+			Limit:   10,
+			Page:    1,
+			Filters: []string{"operation eq update"},
+		}
+
+		expectedResource := resourceful.NewResource[uuid.UUID, dtos.ProductHistoryList](v1.ProductHistoryDefinition)
+		expectedResource.Parameter = &resourceParam
+
+		returnedResource := resourceful.NewResource[uuid.UUID, dtos.ProductHistoryList](v1.ProductHistoryDefinition)
+		err := returnedResource.SetParam(resourceParam)
+		require.NoError(t, err)
+
+		historyUUID := uuid.New()
+		operation, createdBy, createdOn := "update", "cavalry", time.Now()
+		returnedResource.SetResult(resourceful.Result[uuid.UUID, dtos.ProductHistoryList]{
+			Ids: []uuid.UUID{historyUUID},
+			PaginatedResult: []dtos.ProductHistoryList{
+				{
+					UUID:      historyUUID.String(),
+					Operation: &operation,
+					Changes:   &entities.ProductChanges{"price": {Before: 1000, After: 2000}},
+					CreatedOn: &createdOn,
+					CreatedBy: &createdBy,
+				},
+			},
+		})
+
+		productUCMock := mocks.NewMockUseCase(ctrl)
+		productUCMock.EXPECT().History(gomock.Any(), uint64(392), productUUID, expectedResource).Return(returnedResource, nil)
+
+		productHandler := v1.NewProductHandler(config.Config{}, productUCMock)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/product/%s/history?limit=10&page=1&filters=operation+eq+update", productUUID), nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		var contract struct {
+			Metadata struct {
+				Count      int `json:"count"`
+				Page       int `json:"page"`
+				TotalPage  int `json:"total_page"`
+				TotalCount int `json:"total_count"`
+			} `json:"metadata"`
+			Data struct {
+				PaginatedResult []struct {
+					Id        string `json:"id"`
+					Operation string `json:"operation"`
+					Changes   map[string]struct {
+						Before any `json:"before"`
+						After  any `json:"after"`
+					} `json:"changes"`
+					CreatedOn time.Time `json:"created_on"`
+					CreatedBy string    `json:"created_by"`
+				} `json:"paginated_result"`
+				Ids []string `json:"ids"`
+			} `json:"data"`
+		}
+
+		jsonDecoder := json.NewDecoder(response.Body)
+		jsonDecoder.DisallowUnknownFields()
+		err = jsonDecoder.Decode(&contract)
+		require.NoError(t, err)
+
+		require.Len(t, contract.Data.PaginatedResult, 1)
+		assert.Equal(t, historyUUID.String(), contract.Data.PaginatedResult[0].Id)
+		assert.Equal(t, "update", contract.Data.PaginatedResult[0].Operation)
+		assert.Equal(t, float64(2000), contract.Data.PaginatedResult[0].Changes["price"].After)
+		assert.Equal(t, "cavalry", contract.Data.PaginatedResult[0].CreatedBy)
+	})
+}
+
+func TestProductHandler_IndexViews(t *testing.T) {
+	t.Run("contract_test", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		returnedViews := []dtos.ProductViewResponse{
+			dtos.NewDefaultProductViewResponse(tabledefinition.ProductViews[0]),
+			{UUID: uuid.NewString(), Name: "cheap", Filters: []string{"price lt 1000"}, Sorts: []string{}},
+		}
+
+		productUCMock := mocks.NewMockUseCase(ctrl)
+		productUCMock.EXPECT().IndexViews(gomock.Any(), int64(392)).Return(returnedViews, nil)
+
+		productHandler := v1.NewProductHandler(config.Config{}, productUCMock)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/product/views", nil)
+		response, err := app.Test(request)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		var contract struct {
+			Data []struct {
+				Id        string   `json:"id"`
+				Name      string   `json:"name"`
+				Filters   []string `json:"filters"`
+				Sorts     []string `json:"sorts"`
+				IsDefault bool     `json:"is_default"`
+			} `json:"data"`
+		}
+
+		jsonDecoder := json.NewDecoder(response.Body)
+		jsonDecoder.DisallowUnknownFields()
+		err = jsonDecoder.Decode(&contract)
+		require.NoError(t, err)
+
+		require.Len(t, contract.Data, 2)
+		assert.Empty(t, contract.Data[0].Id)
+		assert.True(t, contract.Data[0].IsDefault)
+		assert.Equal(t, returnedViews[1].UUID, contract.Data[1].Id)
+		assert.Equal(t, returnedViews[1].Filters, contract.Data[1].Filters)
+	})
+}
+
+func TestProductHandler_StoreView(t *testing.T) {
+	t.Run("valid_store", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		requestInJSON, err := json.Marshal(dtos.CreateProductViewRequest{
+			Name:    " Cheap ",
+			Filters: []string{"price lt 1000"},
+			Sorts:   []string{"name asc"},
+		})
+		require.NoError(t, err)
+
+		productViewUUID := uuid.NewString()
+
+		mockProductUC := mocks.NewMockUseCase(ctrl)
+		mockProductUC.
+			EXPECT().
+			StoreView(
+				gomock.Any(),
+				identityentities.Credential{
+					UserName:  "cavalry",
+					UserId:    1,
+					CompanyId: 392,
+				},
+				entities.StoreProductView{
+					Name:    "cheap",
+					Filters: []string{"price lt 1000"},
+					Sorts:   []string{"name asc"},
+				},
+			).Return(productViewUUID, nil)
+
+		productHandler := v1.NewProductHandler(config.Config{}, mockProductUC)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		req := httptest.NewRequest(http.MethodPost, "/product/views", strings.NewReader(string(requestInJSON)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("bad_request_when_name_is_empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productHandler := v1.NewProductHandler(config.Config{}, mocks.NewMockUseCase(ctrl))
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		req := httptest.NewRequest(http.MethodPost, "/product/views", strings.NewReader(`{"filters":["price lt 1000"]}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestProductHandler_DeleteView(t *testing.T) {
+	t.Run("Ok_when_valid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productViewUUID := uuid.NewString()
+
+		productUCMock := mocks.NewMockUseCase(ctrl)
+		productUCMock.EXPECT().DeleteView(gomock.Any(), productViewUUID, int64(392)).Return(nil)
+
+		productHandler := v1.NewProductHandler(config.Config{}, productUCMock)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/product/views/%s", productViewUUID), nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
 func TestProductHandler_Update(t *testing.T) {
 	t.Run("ok_when_valid", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -314,7 +1029,7 @@ func TestProductHandler_Update(t *testing.T) {
 					UpdatedOn:   time.Now(),
 					UpdatedBy:   "cavalry",
 				}),
-			).Return(nil)
+			).Return(int64(5), nil)
 
 		productHandler := v1.NewProductHandler(config.Config{}, mockProductUC)
 		app := newFiberApp(392)
@@ -330,7 +1045,104 @@ func TestProductHandler_Update(t *testing.T) {
 		resp, err := app.Test(req)
 		require.NoError(t, err)
 		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, `"5"`, resp.Header.Get(fiber.HeaderETag))
+	})
+
+	t.Run("if_match_version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
+		requestInJSON, err := json.Marshal(dtos.UpdateProductRequest{
+			Name:        "Rinso",
+			Description: "Ini rinso",
+			Price:       2000,
+		})
+		require.NoError(t, err)
+
+		productUUID := uuid.NewString()
+
+		mockProductUC := mocks.NewMockUseCase(ctrl)
+		mockProductUC.
+			EXPECT().
+			Update(gomock.Any(), gomock.Any(), productUUID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, cred identityentities.Credential, productUUID string, payload entities.UpdateProduct) (int64, error) {
+				require.Equal(t, int64(4), payload.Version)
+				return int64(0), apperror.Conflict()
+			})
+
+		productHandler := v1.NewProductHandler(config.Config{}, mockProductUC)
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		req := httptest.NewRequest(
+			http.MethodPatch,
+			fmt.Sprintf("/product/%s", productUUID),
+			strings.NewReader(string(requestInJSON)),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"4"`)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("error_when_if_match_weak", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		requestInJSON, err := json.Marshal(dtos.UpdateProductRequest{
+			Name:        "Rinso",
+			Description: "Ini rinso",
+			Price:       2000,
+		})
+		require.NoError(t, err)
+
+		productHandler := v1.NewProductHandler(config.Config{}, mocks.NewMockUseCase(ctrl))
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		req := httptest.NewRequest(
+			http.MethodPatch,
+			fmt.Sprintf("/product/%s", uuid.NewString()),
+			strings.NewReader(string(requestInJSON)),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `W/"4"`)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	})
+
+	t.Run("error_when_if_match_invalid_or_required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		requestInJSON, err := json.Marshal(dtos.UpdateProductRequest{
+			Name:        "Rinso",
+			Description: "Ini rinso",
+			Price:       2000,
+		})
+		require.NoError(t, err)
+
+		productHandler := v1.NewProductHandler(config.Config{Product: config.ProductConfig{RequireIfMatch: true}}, mocks.NewMockUseCase(ctrl))
+		app := newFiberApp(392)
+		v1.MapProduct(app, productHandler)
+
+		for _, ifMatch := range []string{"", "abc", `"0"`} {
+			req := httptest.NewRequest(
+				http.MethodPatch,
+				fmt.Sprintf("/product/%s", uuid.NewString()),
+				strings.NewReader(string(requestInJSON)),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", ifMatch)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode, ifMatch)
+		}
 	})
 }
 
@@ -7,14 +7,28 @@ import (
 )
 
 var (
-	ProductDefinition *resourceful.Definition
+	ProductDefinition        *resourceful.Definition
+	ProductHistoryDefinition *resourceful.Definition
 )
 
 func NewProductInstance() {
-	productDefinition, err := resourceful.NewDefinition(tabledefinition.Product)
+	productDefinition, err := resourceful.NewDefinition(tabledefinition.Product, resourceful.DefinitionOption{
+		SearchStrategy: resourceful.FULLTEXT_SEARCH,
+		Views:          tabledefinition.ProductViews,
+		Policy:         tabledefinition.ProductPolicy,
+	})
 	if err != nil {
 		log.Println(err)
 	}
 
 	ProductDefinition = productDefinition
+
+	productHistoryDefinition, err := resourceful.NewDefinition(tabledefinition.ProductHistory, resourceful.DefinitionOption{
+		Policy: tabledefinition.ProductPolicy,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+	ProductHistoryDefinition = productHistoryDefinition
 }
@@ -10,7 +10,15 @@ func MapProduct(routes fiber.Router, h product.Handlers) {
 	product := routes.Group("/product")
 	product.Get("/", h.Index)
 	product.Post("/", h.Store)
+	product.Get("/stats", h.Stats)
+	product.Get("/export", h.Export)
+	product.Get("/_meta", h.Meta)
+	product.Get("/views", h.IndexViews)
+	product.Post("/views", h.StoreView)
+	product.Delete("/views/:productViewId", h.DeleteView)
 	product.Get("/:productUUID", h.Show)
 	product.Delete("/:productId", h.Delete)
 	product.Patch("/:productId", h.Update)
+	product.Post("/:productId/restore", h.Restore)
+	product.Get("/:productId/history", h.History)
 }
@@ -0,0 +1,36 @@
+package dtos
+
+import (
+	"mceasy/service-demo/pkg/resourceful"
+
+	"github.com/invopop/validation"
+)
+
+var ExportContentTypes = map[string]string{
+	resourceful.CSV_EXPORT:  "text/csv",
+	resourceful.XLSX_EXPORT: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+type ExportRequest struct {
+	Format  string   `json:"format"`
+	Search  string   `json:"search"`
+	Filters []string `json:"filters"`
+	Sorts   []string `json:"sort"`
+	Fields  []string `json:"fields"`
+	View    string   `json:"view"`
+}
+
+// The format is default to CSV
+func (e *ExportRequest) Mod() *ExportRequest {
+	if e.Format == "" {
+		e.Format = resourceful.CSV_EXPORT
+	}
+
+	return e
+}
+
+func (e ExportRequest) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Format, validation.In(resourceful.CSV_EXPORT, resourceful.XLSX_EXPORT)),
+	)
+}
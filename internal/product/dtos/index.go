@@ -1,18 +1,36 @@
 package dtos
 
-import "github.com/invopop/validation"
+import (
+	"mceasy/service-demo/pkg/resourceful"
+
+	"github.com/invopop/validation"
+)
 
 type IndexRequest struct {
-	Limit   int      `json:"limit"`
-	Page    int      `json:"page"`
-	Search  string   `json:"search"`
-	Filters []string `json:"filters"`
-	Sorts   []string `json:"sort"`
+	Pagination string   `json:"pagination"`
+	Limit      int      `json:"limit"`
+	Page       int      `json:"page"`
+	Cursor     string   `json:"cursor"`
+	Search     string   `json:"search"`
+	Filters    []string `json:"filters"`
+	Sorts      []string `json:"sort"`
+	Fields     []string `json:"fields"`
+	Facets     []string `json:"facets"`
+	Include    []string `json:"include"`
+	View       string   `json:"view"`
+	// Admin only, list the soft deleted products too or the soft deleted products only
+	IncludeDeleted bool `json:"include_deleted" query:"include_deleted"`
+	OnlyDeleted    bool `json:"only_deleted" query:"only_deleted"`
 }
 
 func (i IndexRequest) Validate() error {
+	isCursorPagination := i.Pagination == resourceful.CURSOR_PAGINATION
+
 	return validation.ValidateStruct(&i,
+		validation.Field(&i.Pagination, validation.In(resourceful.PAGE_PAGINATION, resourceful.CURSOR_PAGINATION)),
 		validation.Field(&i.Limit, validation.Required, validation.Min(1)),
-		validation.Field(&i.Page, validation.Required, validation.Min(1)),
+		validation.Field(&i.Page, validation.When(!isCursorPagination, validation.Required, validation.Min(1))),
+		validation.Field(&i.Cursor, validation.When(!isCursorPagination, validation.Empty)),
+		validation.Field(&i.OnlyDeleted, validation.When(i.IncludeDeleted, validation.Empty.Error("can't be used with include_deleted"))),
 	)
 }
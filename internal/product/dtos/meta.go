@@ -0,0 +1,8 @@
+package dtos
+
+import "mceasy/service-demo/pkg/resourceful"
+
+type MetaResponse struct {
+	Definition resourceful.DefinitionMetadata `json:"definition"`
+	Parameters []resourceful.OpenAPIParameter `json:"parameters"`
+}
@@ -0,0 +1,40 @@
+package dtos
+
+import (
+	"mceasy/service-demo/internal/product/entities"
+	"mceasy/service-demo/pkg/resourceful"
+	"time"
+
+	"github.com/invopop/validation"
+)
+
+type HistoryRequest struct {
+	Pagination string   `json:"pagination"`
+	Limit      int      `json:"limit"`
+	Page       int      `json:"page"`
+	Cursor     string   `json:"cursor"`
+	Search     string   `json:"search"`
+	Filters    []string `json:"filters"`
+	Sorts      []string `json:"sort"`
+	Facets     []string `json:"facets"`
+}
+
+func (h HistoryRequest) Validate() error {
+	isCursorPagination := h.Pagination == resourceful.CURSOR_PAGINATION
+
+	return validation.ValidateStruct(&h,
+		validation.Field(&h.Pagination, validation.In(resourceful.PAGE_PAGINATION, resourceful.CURSOR_PAGINATION)),
+		validation.Field(&h.Limit, validation.Required, validation.Min(1)),
+		validation.Field(&h.Page, validation.When(!isCursorPagination, validation.Required, validation.Min(1))),
+		validation.Field(&h.Cursor, validation.When(!isCursorPagination, validation.Empty)),
+	)
+}
+
+// Unselected fields are omitted from the response
+type ProductHistoryList struct {
+	UUID      string                   `json:"id" db:"uuid"`
+	Operation *string                  `json:"operation,omitempty" db:"operation"`
+	Changes   *entities.ProductChanges `json:"changes,omitempty" db:"changes"`
+	CreatedOn *time.Time               `json:"created_on,omitempty" db:"created_on"`
+	CreatedBy *string                  `json:"created_by,omitempty" db:"created_by"`
+}
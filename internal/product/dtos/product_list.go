@@ -1,7 +1,13 @@
 package dtos
 
+import "time"
+
+// Unselected fields are omitted from the response
 type ProductList struct {
-	UUID        string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	UUID        string  `json:"id" db:"uuid"`
+	Name        *string `json:"name,omitempty" db:"name"`
+	Description *string `json:"description,omitempty" db:"description"`
+	Price       *int64  `json:"price,omitempty" db:"price"`
+
+	LastModifiedOn *time.Time `json:"last_modified_on,omitempty" db:"last_modified_on"`
 }
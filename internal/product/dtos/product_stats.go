@@ -0,0 +1,33 @@
+package dtos
+
+import "mceasy/service-demo/pkg/resourceful"
+
+type StatsRequest struct {
+	Search  string   `json:"search"`
+	Filters []string `json:"filters"`
+}
+
+type ProductStats struct {
+	Count        int64   `json:"count"`
+	TotalPrice   float64 `json:"total_price"`
+	AveragePrice float64 `json:"average_price"`
+	MinPrice     float64 `json:"min_price"`
+	MaxPrice     float64 `json:"max_price"`
+}
+
+// The NULL aggregation (no product) is set to 0
+func NewProductStats(bucket resourceful.Bucket) ProductStats {
+	count, _ := bucket.Values["count"].(int64)
+	totalPrice, _ := bucket.Values["total_price"].(float64)
+	averagePrice, _ := bucket.Values["average_price"].(float64)
+	minPrice, _ := bucket.Values["min_price"].(float64)
+	maxPrice, _ := bucket.Values["max_price"].(float64)
+
+	return ProductStats{
+		Count:        count,
+		TotalPrice:   totalPrice,
+		AveragePrice: averagePrice,
+		MinPrice:     minPrice,
+		MaxPrice:     maxPrice,
+	}
+}
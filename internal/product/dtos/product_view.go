@@ -0,0 +1,56 @@
+package dtos
+
+import (
+	"mceasy/service-demo/internal/product/entities"
+	"mceasy/service-demo/pkg/resourceful"
+	"strings"
+
+	"github.com/invopop/validation"
+)
+
+type CreateProductViewRequest struct {
+	Name    string   `json:"name"`
+	Filters []string `json:"filters"`
+	Sorts   []string `json:"sorts"`
+}
+
+func (c *CreateProductViewRequest) Mod() *CreateProductViewRequest {
+	c.Name = strings.ToLower(strings.TrimSpace(c.Name))
+
+	return c
+}
+
+func (c CreateProductViewRequest) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required, validation.Length(0, 50)),
+		validation.Field(&c.Filters, validation.Length(0, 10)),
+		validation.Field(&c.Sorts, validation.Length(0, 5)),
+	)
+}
+
+// The code registered view has no id & can't be deleted
+type ProductViewResponse struct {
+	UUID      string   `json:"id,omitempty"`
+	Name      string   `json:"name"`
+	Filters   []string `json:"filters"`
+	Sorts     []string `json:"sorts"`
+	IsDefault bool     `json:"is_default"`
+}
+
+func NewDefaultProductViewResponse(view resourceful.View) ProductViewResponse {
+	return ProductViewResponse{
+		Name:      view.Name,
+		Filters:   append([]string{}, view.Filters...),
+		Sorts:     append([]string{}, view.Sorts...),
+		IsDefault: true,
+	}
+}
+
+func NewProductViewResponse(entity entities.ProductView) ProductViewResponse {
+	return ProductViewResponse{
+		UUID:    entity.UUID,
+		Name:    entity.Name,
+		Filters: append([]string{}, entity.Filters...),
+		Sorts:   append([]string{}, entity.Sorts...),
+	}
+}
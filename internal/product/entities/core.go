@@ -8,6 +8,7 @@ type Product struct {
 	Name        string    `db:"name"`
 	Description string    `db:"description"`
 	Price       int64     `db:"price"`
+	Version     int64     `db:"version"`
 	CreatedOn   time.Time `db:"created_on"`
 	CreatedBy   string    `db:"created_by"`
 	UpdatedOn   time.Time `db:"updated_on"`
@@ -20,6 +20,7 @@ func NewProduct(cred identityentities.Credential, storeProduct StoreProduct) Pro
 		Name:        storeProduct.Name,
 		Description: storeProduct.Description,
 		Price:       storeProduct.Price,
+		Version:     1, // The version column default
 		CreatedOn:   time.Now(),
 		CreatedBy:   cred.UserName,
 		UpdatedOn:   time.Now(),
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+type DeleteProduct struct {
+	CompanyId int64     `db:"company_id"`
+	UUID      string    `db:"uuid"`
+	DeletedOn time.Time `db:"deleted_on"`
+	DeletedBy string    `db:"deleted_by"`
+}
+
+type RestoreProduct struct {
+	CompanyId int64     `db:"company_id"`
+	UUID      string    `db:"uuid"`
+	UpdatedOn time.Time `db:"updated_on"`
+	UpdatedBy string    `db:"updated_by"`
+}
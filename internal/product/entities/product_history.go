@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"mceasy/service-demo/internal/identity/identityentities"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Product history operations
+const (
+	ProductHistoryCreate  = "create"
+	ProductHistoryUpdate  = "update"
+	ProductHistoryDelete  = "delete"
+	ProductHistoryRestore = "restore"
+)
+
+// ProductHistory is the audit row of the product write, written in the same transaction as the write
+type ProductHistory struct {
+	CompanyId   int64          `db:"company_id"`
+	UUID        string         `db:"uuid"`
+	ProductUUID string         `db:"product_uuid"`
+	Operation   string         `db:"operation"`
+	Changes     ProductChanges `db:"changes"`
+	CreatedOn   time.Time      `db:"created_on"`
+	CreatedBy   string         `db:"created_by"`
+}
+
+type ProductChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// The changed fields keyed by the field name, stored as the jsonb
+type ProductChanges map[string]ProductChange
+
+func (p ProductChanges) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+func (p *ProductChanges) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, p)
+	case string:
+		return json.Unmarshal([]byte(src), p)
+	case nil:
+		*p = nil
+		return nil
+	}
+
+	return fmt.Errorf("unsupported %T product changes", src)
+}
+
+// Create the history of the product write with the changed fields. The before product is nil on the create &
+// restore, the after product is nil on the delete
+func NewProductHistory(cred identityentities.Credential, operation string, before, after *Product) ProductHistory {
+	productUUID := ""
+	beforeFields, afterFields := productHistoryFields(before), productHistoryFields(after)
+	if before != nil {
+		productUUID = before.UUID
+	} else if after != nil {
+		productUUID = after.UUID
+	}
+
+	changes := ProductChanges{}
+	for _, field := range []string{"name", "description", "price"} {
+		beforeValue, afterValue := beforeFields[field], afterFields[field]
+		if before != nil && after != nil && beforeValue == afterValue {
+			continue
+		}
+
+		changes[field] = ProductChange{Before: beforeValue, After: afterValue}
+	}
+
+	return ProductHistory{
+		CompanyId:   int64(cred.CompanyId),
+		UUID:        uuid.NewString(),
+		ProductUUID: productUUID,
+		Operation:   operation,
+		Changes:     changes,
+		CreatedOn:   time.Now(),
+		CreatedBy:   cred.UserName,
+	}
+}
+
+func productHistoryFields(product *Product) map[string]any {
+	if product == nil {
+		return map[string]any{}
+	}
+
+	return map[string]any{
+		"name":        product.Name,
+		"description": product.Description,
+		"price":       product.Price,
+	}
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"mceasy/service-demo/internal/identity/identityentities"
+	"mceasy/service-demo/pkg/resourceful"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ProductView is the per company saved filters & sorts preset of the product index
+type ProductView struct {
+	CompanyId int64          `db:"company_id"`
+	UUID      string         `db:"uuid"`
+	Name      string         `db:"name"`
+	Filters   pq.StringArray `db:"filters"`
+	Sorts     pq.StringArray `db:"sorts"`
+	CreatedOn time.Time      `db:"created_on"`
+	CreatedBy string         `db:"created_by"`
+}
+
+func (p ProductView) View() resourceful.View {
+	return resourceful.View{
+		Name:    p.Name,
+		Filters: p.Filters,
+		Sorts:   p.Sorts,
+	}
+}
+
+type StoreProductView struct {
+	Name    string
+	Filters []string
+	Sorts   []string
+}
+
+func NewProductView(cred identityentities.Credential, storeProductView StoreProductView) ProductView {
+	filters := pq.StringArray{}
+	filters = append(filters, storeProductView.Filters...)
+	sorts := pq.StringArray{}
+	sorts = append(sorts, storeProductView.Sorts...)
+
+	return ProductView{
+		CompanyId: int64(cred.CompanyId),
+		UUID:      uuid.NewString(),
+		Name:      storeProductView.Name,
+		Filters:   filters,
+		Sorts:     sorts,
+		CreatedOn: time.Now(),
+		CreatedBy: cred.UserName,
+	}
+}
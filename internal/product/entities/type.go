@@ -7,4 +7,6 @@ type ResponseData struct {
 
 type GetProductOption struct {
 	PessimisticLocking bool
+	// Get the soft deleted product instead, e.g. to restore the product
+	IsDeleted bool
 }
@@ -10,4 +10,6 @@ type UpdateProduct struct {
 	Price       int64     `db:"price"`
 	UpdatedOn   time.Time `db:"updated_on"`
 	UpdatedBy   string    `db:"updated_by"`
+	// The expected product version (the If-Match), the zero version updates any version
+	Version int64 `db:"version"`
 }
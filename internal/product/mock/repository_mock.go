@@ -7,11 +7,13 @@ package mocks
 import (
 	context "context"
 	sql "database/sql"
+	io "io"
 	product "mceasy/service-demo/internal/product"
 	dtos "mceasy/service-demo/internal/product/dtos"
 	entities "mceasy/service-demo/internal/product/entities"
 	resourceful "mceasy/service-demo/pkg/resourceful"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -55,17 +57,60 @@ func (mr *MockRepositoryMockRecorder) Atomic(ctx, opt, cb interface{}) *gomock.C
 }
 
 // DeleteProductByUUID mocks base method.
-func (m *MockRepository) DeleteProductByUUID(ctx context.Context, productUUID string, companyId int64) error {
+func (m *MockRepository) DeleteProductByUUID(ctx context.Context, product entities.DeleteProduct) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProductByUUID", ctx, productUUID, companyId)
+	ret := m.ctrl.Call(m, "DeleteProductByUUID", ctx, product)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProductByUUID indicates an expected call of DeleteProductByUUID.
-func (mr *MockRepositoryMockRecorder) DeleteProductByUUID(ctx, productUUID, companyId interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) DeleteProductByUUID(ctx, product interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductByUUID", reflect.TypeOf((*MockRepository)(nil).DeleteProductByUUID), ctx, productUUID, companyId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductByUUID", reflect.TypeOf((*MockRepository)(nil).DeleteProductByUUID), ctx, product)
+}
+
+// DeleteProductViewByUUID mocks base method.
+func (m *MockRepository) DeleteProductViewByUUID(ctx context.Context, productViewUUID string, companyId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductViewByUUID", ctx, productViewUUID, companyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductViewByUUID indicates an expected call of DeleteProductViewByUUID.
+func (mr *MockRepositoryMockRecorder) DeleteProductViewByUUID(ctx, productViewUUID, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductViewByUUID", reflect.TypeOf((*MockRepository)(nil).DeleteProductViewByUUID), ctx, productViewUUID, companyId)
+}
+
+// ExportProductResourceful mocks base method.
+func (m *MockRepository) ExportProductResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductList], w io.Writer, format string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProductResourceful", ctx, resource, w, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProductResourceful indicates an expected call of ExportProductResourceful.
+func (mr *MockRepositoryMockRecorder) ExportProductResourceful(ctx, resource, w, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProductResourceful", reflect.TypeOf((*MockRepository)(nil).ExportProductResourceful), ctx, resource, w, format)
+}
+
+// FindProductHistoryResourceful mocks base method.
+func (m *MockRepository) FindProductHistoryResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductHistoryList]) (*resourceful.Resource[uuid.UUID, dtos.ProductHistoryList], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProductHistoryResourceful", ctx, resource)
+	ret0, _ := ret[0].(*resourceful.Resource[uuid.UUID, dtos.ProductHistoryList])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProductHistoryResourceful indicates an expected call of FindProductHistoryResourceful.
+func (mr *MockRepositoryMockRecorder) FindProductHistoryResourceful(ctx, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductHistoryResourceful", reflect.TypeOf((*MockRepository)(nil).FindProductHistoryResourceful), ctx, resource)
 }
 
 // FindProductResourceful mocks base method.
@@ -83,6 +128,21 @@ func (mr *MockRepositoryMockRecorder) FindProductResourceful(ctx, resource inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductResourceful", reflect.TypeOf((*MockRepository)(nil).FindProductResourceful), ctx, resource)
 }
 
+// FindProductViews mocks base method.
+func (m *MockRepository) FindProductViews(ctx context.Context, companyId int64) ([]entities.ProductView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProductViews", ctx, companyId)
+	ret0, _ := ret[0].([]entities.ProductView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProductViews indicates an expected call of FindProductViews.
+func (mr *MockRepositoryMockRecorder) FindProductViews(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductViews", reflect.TypeOf((*MockRepository)(nil).FindProductViews), ctx, companyId)
+}
+
 // GetProductByUUID mocks base method.
 func (m *MockRepository) GetProductByUUID(ctx context.Context, productUUID string, companyId int64, options ...entities.GetProductOption) (entities.Product, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +163,21 @@ func (mr *MockRepositoryMockRecorder) GetProductByUUID(ctx, productUUID, company
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByUUID", reflect.TypeOf((*MockRepository)(nil).GetProductByUUID), varargs...)
 }
 
+// GetProductStatsResourceful mocks base method.
+func (m *MockRepository) GetProductStatsResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (dtos.ProductStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductStatsResourceful", ctx, resource)
+	ret0, _ := ret[0].(dtos.ProductStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductStatsResourceful indicates an expected call of GetProductStatsResourceful.
+func (mr *MockRepositoryMockRecorder) GetProductStatsResourceful(ctx, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductStatsResourceful", reflect.TypeOf((*MockRepository)(nil).GetProductStatsResourceful), ctx, resource)
+}
+
 // IsProductKeyExists mocks base method.
 func (m *MockRepository) IsProductKeyExists(ctx context.Context, payload entities.StoreProduct, companyId int64) (bool, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +193,35 @@ func (mr *MockRepositoryMockRecorder) IsProductKeyExists(ctx, payload, companyId
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProductKeyExists", reflect.TypeOf((*MockRepository)(nil).IsProductKeyExists), ctx, payload, companyId)
 }
 
+// PurgeDeletedProducts mocks base method.
+func (m *MockRepository) PurgeDeletedProducts(ctx context.Context, deletedBefore time.Time, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedProducts", ctx, deletedBefore, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedProducts indicates an expected call of PurgeDeletedProducts.
+func (mr *MockRepositoryMockRecorder) PurgeDeletedProducts(ctx, deletedBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedProducts", reflect.TypeOf((*MockRepository)(nil).PurgeDeletedProducts), ctx, deletedBefore, limit)
+}
+
+// RestoreProductByUUID mocks base method.
+func (m *MockRepository) RestoreProductByUUID(ctx context.Context, product entities.RestoreProduct) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProductByUUID", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreProductByUUID indicates an expected call of RestoreProductByUUID.
+func (mr *MockRepositoryMockRecorder) RestoreProductByUUID(ctx, product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProductByUUID", reflect.TypeOf((*MockRepository)(nil).RestoreProductByUUID), ctx, product)
+}
+
 // StoreNewProduct mocks base method.
 func (m *MockRepository) StoreNewProduct(ctx context.Context, product entities.Product) (string, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +237,35 @@ func (mr *MockRepositoryMockRecorder) StoreNewProduct(ctx, product interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreNewProduct", reflect.TypeOf((*MockRepository)(nil).StoreNewProduct), ctx, product)
 }
 
+// StoreNewProductHistory mocks base method.
+func (m *MockRepository) StoreNewProductHistory(ctx context.Context, productHistory entities.ProductHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreNewProductHistory", ctx, productHistory)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreNewProductHistory indicates an expected call of StoreNewProductHistory.
+func (mr *MockRepositoryMockRecorder) StoreNewProductHistory(ctx, productHistory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreNewProductHistory", reflect.TypeOf((*MockRepository)(nil).StoreNewProductHistory), ctx, productHistory)
+}
+
+// StoreNewProductView mocks base method.
+func (m *MockRepository) StoreNewProductView(ctx context.Context, productView entities.ProductView) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreNewProductView", ctx, productView)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreNewProductView indicates an expected call of StoreNewProductView.
+func (mr *MockRepositoryMockRecorder) StoreNewProductView(ctx, productView interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreNewProductView", reflect.TypeOf((*MockRepository)(nil).StoreNewProductView), ctx, productView)
+}
+
 // UpdateProductByUUID mocks base method.
 func (m *MockRepository) UpdateProductByUUID(ctx context.Context, product entities.UpdateProduct) error {
 	m.ctrl.T.Helper()
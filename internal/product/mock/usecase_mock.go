@@ -6,11 +6,13 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	identityentities "mceasy/service-demo/internal/identity/identityentities"
 	dtos "mceasy/service-demo/internal/product/dtos"
 	entities "mceasy/service-demo/internal/product/entities"
 	resourceful "mceasy/service-demo/pkg/resourceful"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -40,17 +42,61 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 }
 
 // Delete mocks base method.
-func (m *MockUseCase) Delete(ctx context.Context, productUUID string, companyId int64) error {
+func (m *MockUseCase) Delete(ctx context.Context, requestCredential identityentities.Credential, productUUID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, productUUID, companyId)
+	ret := m.ctrl.Call(m, "Delete", ctx, requestCredential, productUUID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUseCaseMockRecorder) Delete(ctx, productUUID, companyId interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) Delete(ctx, requestCredential, productUUID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase)(nil).Delete), ctx, productUUID, companyId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase)(nil).Delete), ctx, requestCredential, productUUID)
+}
+
+// DeleteView mocks base method.
+func (m *MockUseCase) DeleteView(ctx context.Context, productViewUUID string, companyId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteView", ctx, productViewUUID, companyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteView indicates an expected call of DeleteView.
+func (mr *MockUseCaseMockRecorder) DeleteView(ctx, productViewUUID, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockUseCase)(nil).DeleteView), ctx, productViewUUID, companyId)
+}
+
+// Export mocks base method.
+func (m *MockUseCase) Export(ctx context.Context, companyId uint64, resource *resourceful.Resource[uuid.UUID, dtos.ProductList], format string) (func(context.Context, io.Writer) error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, companyId, resource, format)
+	ret0, _ := ret[0].(func(context.Context, io.Writer) error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUseCaseMockRecorder) Export(ctx, companyId, resource, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUseCase)(nil).Export), ctx, companyId, resource, format)
+}
+
+// History mocks base method.
+func (m *MockUseCase) History(ctx context.Context, companyId uint64, productUUID string, resource *resourceful.Resource[uuid.UUID, dtos.ProductHistoryList]) (*resourceful.Resource[uuid.UUID, dtos.ProductHistoryList], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, companyId, productUUID, resource)
+	ret0, _ := ret[0].(*resourceful.Resource[uuid.UUID, dtos.ProductHistoryList])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockUseCaseMockRecorder) History(ctx, companyId, productUUID, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockUseCase)(nil).History), ctx, companyId, productUUID, resource)
 }
 
 // Index mocks base method.
@@ -68,6 +114,50 @@ func (mr *MockUseCaseMockRecorder) Index(ctx, companyId, resource interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockUseCase)(nil).Index), ctx, companyId, resource)
 }
 
+// IndexViews mocks base method.
+func (m *MockUseCase) IndexViews(ctx context.Context, companyId int64) ([]dtos.ProductViewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexViews", ctx, companyId)
+	ret0, _ := ret[0].([]dtos.ProductViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexViews indicates an expected call of IndexViews.
+func (mr *MockUseCaseMockRecorder) IndexViews(ctx, companyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexViews", reflect.TypeOf((*MockUseCase)(nil).IndexViews), ctx, companyId)
+}
+
+// Purge mocks base method.
+func (m *MockUseCase) Purge(ctx context.Context, retention time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, retention)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockUseCaseMockRecorder) Purge(ctx, retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockUseCase)(nil).Purge), ctx, retention)
+}
+
+// Restore mocks base method.
+func (m *MockUseCase) Restore(ctx context.Context, requestCredential identityentities.Credential, productUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, requestCredential, productUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUseCaseMockRecorder) Restore(ctx, requestCredential, productUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUseCase)(nil).Restore), ctx, requestCredential, productUUID)
+}
+
 // Show mocks base method.
 func (m *MockUseCase) Show(ctx context.Context, productUUID string, companyId int64) (entities.Product, error) {
 	m.ctrl.T.Helper()
@@ -83,6 +173,21 @@ func (mr *MockUseCaseMockRecorder) Show(ctx, productUUID, companyId interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Show", reflect.TypeOf((*MockUseCase)(nil).Show), ctx, productUUID, companyId)
 }
 
+// Stats mocks base method.
+func (m *MockUseCase) Stats(ctx context.Context, companyId uint64, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (dtos.ProductStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, companyId, resource)
+	ret0, _ := ret[0].(dtos.ProductStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockUseCaseMockRecorder) Stats(ctx, companyId, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockUseCase)(nil).Stats), ctx, companyId, resource)
+}
+
 // Store mocks base method.
 func (m *MockUseCase) Store(ctx context.Context, requestCredential identityentities.Credential, payload entities.StoreProduct) (string, error) {
 	m.ctrl.T.Helper()
@@ -98,12 +203,28 @@ func (mr *MockUseCaseMockRecorder) Store(ctx, requestCredential, payload interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockUseCase)(nil).Store), ctx, requestCredential, payload)
 }
 
+// StoreView mocks base method.
+func (m *MockUseCase) StoreView(ctx context.Context, requestCredential identityentities.Credential, payload entities.StoreProductView) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreView", ctx, requestCredential, payload)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreView indicates an expected call of StoreView.
+func (mr *MockUseCaseMockRecorder) StoreView(ctx, requestCredential, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreView", reflect.TypeOf((*MockUseCase)(nil).StoreView), ctx, requestCredential, payload)
+}
+
 // Update mocks base method.
-func (m *MockUseCase) Update(ctx context.Context, requestCredential identityentities.Credential, productUUID string, payload entities.UpdateProduct) error {
+func (m *MockUseCase) Update(ctx context.Context, requestCredential identityentities.Credential, productUUID string, payload entities.UpdateProduct) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, requestCredential, productUUID, payload)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
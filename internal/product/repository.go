@@ -3,9 +3,11 @@ package product
 import (
 	"context"
 	"database/sql"
+	"io"
 	"mceasy/service-demo/internal/product/dtos"
 	"mceasy/service-demo/internal/product/entities"
 	"mceasy/service-demo/pkg/resourceful"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,9 +17,20 @@ type Repository interface {
 
 	GetProductByUUID(ctx context.Context, productUUID string, companyId int64, options ...entities.GetProductOption) (entities.Product, error)
 	StoreNewProduct(ctx context.Context, product entities.Product) (string, error)
-	DeleteProductByUUID(ctx context.Context, productUUID string, companyId int64) error
+	DeleteProductByUUID(ctx context.Context, product entities.DeleteProduct) error
+	RestoreProductByUUID(ctx context.Context, product entities.RestoreProduct) error
+	PurgeDeletedProducts(ctx context.Context, deletedBefore time.Time, limit int) ([]int64, error)
 	UpdateProductByUUID(ctx context.Context, product entities.UpdateProduct) error
 
 	FindProductResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (*resourceful.Resource[uuid.UUID, dtos.ProductList], error)
+	GetProductStatsResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (dtos.ProductStats, error)
+	ExportProductResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductList], w io.Writer, format string) error
 	IsProductKeyExists(ctx context.Context, payload entities.StoreProduct, companyId int64) (bool, error)
+
+	StoreNewProductHistory(ctx context.Context, productHistory entities.ProductHistory) error
+	FindProductHistoryResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductHistoryList]) (*resourceful.Resource[uuid.UUID, dtos.ProductHistoryList], error)
+
+	FindProductViews(ctx context.Context, companyId int64) ([]entities.ProductView, error)
+	StoreNewProductView(ctx context.Context, productView entities.ProductView) (string, error)
+	DeleteProductViewByUUID(ctx context.Context, productViewUUID string, companyId int64) error
 }
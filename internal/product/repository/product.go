@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"mceasy/service-demo/internal/product"
 	"mceasy/service-demo/internal/product/dtos"
 	"mceasy/service-demo/internal/product/entities"
@@ -12,12 +13,17 @@ import (
 	"mceasy/service-demo/pkg/database"
 	"mceasy/service-demo/pkg/observability/instrumentation"
 	"mceasy/service-demo/pkg/resourceful"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
+// The postgres error code of the unique constraint violation
+const uniqueViolationCode = "23505"
+
 func NewProductPGRepo(db *sqlx.DB) product.Repository {
 	return &productRepo{
 		conn: db,
@@ -119,7 +125,10 @@ func (r *productRepo) GetProductByUUID(ctx context.Context, productUUID string,
 	)
 	defer span.End()
 
-	query := getProductByUUID
+	query := getProductByUUID + "\tAND p.deleted_on IS NULL"
+	if len(options) >= 1 && options[0].IsDeleted {
+		query = getProductByUUID + "\tAND p.deleted_on IS NOT NULL"
+	}
 	if len(options) >= 1 && options[0].PessimisticLocking {
 		query += " FOR UPDATE"
 	}
@@ -152,61 +161,128 @@ func (r *productRepo) FindProductResourceful(ctx context.Context, resource *reso
 	)
 	defer span.End()
 
-	resource.Select([]*resourceful.Field{
-		tabledefinition.Product.Field("uuid"),
-	})
+	err := r.readOnly(ctx, func(db database.Queryer) error {
+		executor := resourceful.NewExecutor[uuid.UUID, dtos.ProductList](
+			db,
+			tabledefinition.Product.Field("uuid"),
+			[]*resourceful.Field{
+				tabledefinition.Product.Field("name"),
+				tabledefinition.Product.Field("description"),
+			},
+		)
 
-	query, args, err := resource.QueryAndArgs()
+		return executor.Find(ctx, resource)
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "productRepo.FindProductResourceful.QueryAndArgsResourceful")
+		return nil, errors.Wrap(err, "productRepo.FindProductResourceful.ExecutorFind")
 	}
 
-	var uuids []uuid.UUID
-	err = r.db.SelectContext(ctx, &uuids, query, args...)
+	return resource, nil
+}
+
+// Run the read queries inside the read only transaction, so the resourceful statement timeout (SET LOCAL)
+// only lives for the queries. The Atomic transaction is reused
+func (r *productRepo) readOnly(ctx context.Context, cb func(db database.Queryer) error) error {
+	if _, ok := r.db.(*sqlx.Tx); ok {
+		return cb(r.db)
+	}
+
+	tx, err := r.conn.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
-		return nil, errors.Wrap(err, "productRepo.FindProductResourceful.SelectContextDB")
+		return errors.Wrap(err, "productRepo.readOnly.BeginTxx")
 	}
+	defer tx.Rollback()
 
-	paginatedIds, err := resource.GetPaginatedResults(uuids)
+	err = cb(tx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	resource.Select([]*resourceful.Field{
-		tabledefinition.Product.Field("uuid"),
-		tabledefinition.Product.Field("name"),
-		tabledefinition.Product.Field("description"),
+	return tx.Commit()
+}
+
+func (r *productRepo) ExportProductResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductList], w io.Writer, format string) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"ExportProductResourcefulRepo",
+	)
+	defer span.End()
+
+	// The export cursor only lives inside the transaction
+	err := r.readOnly(ctx, func(db database.Queryer) error {
+		executor := resourceful.NewExecutor[uuid.UUID, dtos.ProductList](
+			db,
+			tabledefinition.Product.Field("uuid"),
+			[]*resourceful.Field{
+				tabledefinition.Product.Field("name"),
+				tabledefinition.Product.Field("description"),
+				tabledefinition.Product.Field("price"),
+			},
+		)
+
+		return executor.Export(ctx, resource, w, format)
 	})
+	if err != nil {
+		return errors.Wrap(err, "productRepo.ExportProductResourceful.ExecutorExport")
+	}
+
+	return nil
+}
 
-	query, args, err = resource.PopulateQueryArgs(tabledefinition.Product.Field("uuid"), paginatedIds)
+func (r *productRepo) GetProductStatsResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (dtos.ProductStats, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"GetProductStatsResourcefulRepo",
+	)
+	defer span.End()
+
+	priceField := tabledefinition.Product.Field("price")
+	err := resource.Aggregate(nil,
+		resourceful.Aggregation{Name: "count", Function: resourceful.COUNT},
+		resourceful.Aggregation{Name: "total_price", Function: resourceful.SUM, Field: priceField},
+		resourceful.Aggregation{Name: "average_price", Function: resourceful.AVG, Field: priceField},
+		resourceful.Aggregation{Name: "min_price", Function: resourceful.MIN, Field: priceField},
+		resourceful.Aggregation{Name: "max_price", Function: resourceful.MAX, Field: priceField},
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "productRepo.FindProductResourceful.PopulateQueryArgsResourceful")
+		return dtos.ProductStats{}, errors.Wrap(err, "productRepo.GetProductStatsResourceful.AggregateResourceful")
 	}
 
-	var products []dtos.ProductList
-	rows, err := r.db.QueryxContext(ctx, query, args...)
+	query, args, err := resource.AggregateQueryAndArgs()
 	if err != nil {
-		return nil, errors.Wrap(err, "productRepo.FindProductResourceful.QueryContextDB")
+		return dtos.ProductStats{}, errors.Wrap(err, "productRepo.GetProductStatsResourceful.AggregateQueryAndArgsResourceful")
 	}
 
-	for rows.Next() {
-		var product dtos.ProductList
+	var buckets []resourceful.Bucket
+	err = r.readOnly(ctx, func(db database.Queryer) error {
+		err := resource.SetStatementTimeout(ctx, db)
+		if err != nil {
+			return errors.Wrap(err, "productRepo.GetProductStatsResourceful.SetStatementTimeout")
+		}
 
-		err := rows.Scan(
-			&product.UUID,
-			&product.Name,
-			&product.Description,
-		)
+		rows, err := db.QueryxContext(ctx, query, args...)
+		if err != nil {
+			return errors.Wrap(err, "productRepo.GetProductStatsResourceful.QueryContextDB")
+		}
+		defer rows.Close()
+
+		buckets, err = resource.ScanAggregateRows(rows)
 		if err != nil {
-			return nil, errors.Wrap(err, "productRepo.FindProductResourceful.QueryContextDB")
+			return errors.Wrap(err, "productRepo.GetProductStatsResourceful.ScanAggregateRowsResourceful")
 		}
 
-		products = append(products, product)
+		return nil
+	})
+	if err != nil {
+		return dtos.ProductStats{}, err
 	}
 
-	resource.SetResult(resourceful.Result[uuid.UUID, dtos.ProductList]{Ids: uuids, PaginatedResult: products})
-	return resource, nil
+	// Aggregation without group by always returns a single bucket
+	if len(buckets) == 0 {
+		return dtos.ProductStats{}, nil
+	}
 
+	return dtos.NewProductStats(buckets[0]), nil
 }
 
 func (r *productRepo) IsProductKeyExists(ctx context.Context, payload entities.StoreProduct, companyId int64) (bool, error) {
@@ -265,7 +341,8 @@ func (r *productRepo) UpdateProductByUUID(ctx context.Context, product entities.
 	return nil
 }
 
-func (r *productRepo) DeleteProductByUUID(ctx context.Context, productUUID string, companyId int64) error {
+// Soft delete the product, the deleted product is purged after the retention period
+func (r *productRepo) DeleteProductByUUID(ctx context.Context, product entities.DeleteProduct) error {
 	ctx, span := instrumentation.NewTraceSpan(
 		ctx,
 		"DeleteProductByUUIDRepo",
@@ -278,14 +355,203 @@ func (r *productRepo) DeleteProductByUUID(ctx context.Context, productUUID strin
 		ctx,
 		&returnedProductUUID,
 		deleteProductByUUID,
-		productUUID,
+		product.UUID,
+		product.CompanyId,
+		product.DeletedOn,
+		product.DeletedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFound()
+		}
+		return errors.Wrap(err, "productRepo.DeleteProductByUUID.GetContext.deleteProductByUUID")
+	}
+
+	return nil
+}
+
+func (r *productRepo) RestoreProductByUUID(ctx context.Context, product entities.RestoreProduct) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"RestoreProductByUUIDRepo",
+	)
+	defer span.End()
+
+	var returnedProductUUID string
+	err := r.db.GetContext(
+		ctx,
+		&returnedProductUUID,
+		restoreProductByUUID,
+		product.UUID,
+		product.CompanyId,
+		product.UpdatedOn,
+		product.UpdatedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFound()
+		}
+		return errors.Wrap(err, "productRepo.RestoreProductByUUID.GetContext.restoreProductByUUID")
+	}
+
+	return nil
+}
+
+// Hard delete the products soft deleted before the time, at most the limit per call.
+// Return the company id of each purged product
+func (r *productRepo) PurgeDeletedProducts(ctx context.Context, deletedBefore time.Time, limit int) ([]int64, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"PurgeDeletedProductsRepo",
+	)
+	defer span.End()
+
+	companyIds := make([]int64, 0)
+	err := r.db.SelectContext(ctx,
+		&companyIds,
+		purgeDeletedProducts,
+		deletedBefore,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "productRepo.PurgeDeletedProducts.SelectContext.purgeDeletedProducts")
+	}
+
+	return companyIds, nil
+}
+
+func (r *productRepo) StoreNewProductHistory(ctx context.Context, productHistory entities.ProductHistory) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"StoreNewProductHistoryRepo",
+	)
+	defer span.End()
+
+	query, args, err := sqlx.Named(insertProductHistory, productHistory)
+	if err != nil {
+		return errors.Wrap(err, "productRepo.StoreNewProductHistory.sqlxNamed")
+	}
+
+	query = r.db.Rebind(query)
+
+	var returnedId string
+	err = r.db.GetContext(
+		ctx,
+		&returnedId,
+		query,
+		args...,
+	)
+	if err != nil {
+		return errors.Wrap(err, "productRepo.StoreNewProductHistory.GetContext")
+	}
+
+	return nil
+}
+
+func (r *productRepo) FindProductHistoryResourceful(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductHistoryList]) (*resourceful.Resource[uuid.UUID, dtos.ProductHistoryList], error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"FindProductHistoryResourcefulRepo",
+	)
+	defer span.End()
+
+	err := r.readOnly(ctx, func(db database.Queryer) error {
+		executor := resourceful.NewExecutor[uuid.UUID, dtos.ProductHistoryList](
+			db,
+			tabledefinition.ProductHistory.Field("uuid"),
+			[]*resourceful.Field{
+				tabledefinition.ProductHistory.Field("operation"),
+				tabledefinition.ProductHistory.Field("changes"),
+				tabledefinition.ProductHistory.Field("created_on"),
+				tabledefinition.ProductHistory.Field("created_by"),
+			},
+		)
+
+		return executor.Find(ctx, resource)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "productRepo.FindProductHistoryResourceful.ExecutorFind")
+	}
+
+	return resource, nil
+}
+
+func (r *productRepo) FindProductViews(ctx context.Context, companyId int64) ([]entities.ProductView, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"FindProductViewsRepo",
+	)
+	defer span.End()
+
+	productViews := make([]entities.ProductView, 0)
+	err := r.db.SelectContext(ctx,
+		&productViews,
+		findProductViews,
+		companyId,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "productRepo.FindProductViews.SelectContext.findProductViews")
+	}
+
+	return productViews, nil
+}
+
+func (r *productRepo) StoreNewProductView(ctx context.Context, productView entities.ProductView) (string, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"StoreNewProductViewRepo",
+	)
+	defer span.End()
+
+	query, args, err := sqlx.Named(insertProductView, productView)
+	if err != nil {
+		return "", errors.Wrap(err, "productRepo.StoreNewProductView.sqlxNamed")
+	}
+
+	query = r.db.Rebind(query)
+
+	var returnedId string
+	err = r.db.GetContext(
+		ctx,
+		&returnedId,
+		query,
+		args...,
+	)
+	if err != nil {
+		// The concurrent view of the same name is rejected by the company_id & name unique constraint
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+			return "", apperror.BadRequestMap(map[string][]string{
+				"name": {"already exists"},
+			})
+		}
+
+		return "", errors.Wrap(err, "productRepo.StoreNewProductView.GetContext")
+	}
+
+	return returnedId, nil
+}
+
+func (r *productRepo) DeleteProductViewByUUID(ctx context.Context, productViewUUID string, companyId int64) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"DeleteProductViewByUUIDRepo",
+	)
+	defer span.End()
+
+	var returnedProductViewUUID string
+	err := r.db.GetContext(
+		ctx,
+		&returnedProductViewUUID,
+		deleteProductViewByUUID,
+		productViewUUID,
 		companyId,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return apperror.AppError{}
+			return apperror.NotFound()
 		}
-		return err
+		return errors.Wrap(err, "productRepo.DeleteProductViewByUUID.GetContext.deleteProductViewByUUID")
 	}
 
 	return nil
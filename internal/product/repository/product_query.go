@@ -6,6 +6,7 @@ SELECT
 FROM product p
 WHERE p.company_id = $1
 	AND LOWER(p.name) = LOWER($2)
+	AND p.deleted_on IS NULL
 
 `
 
@@ -42,6 +43,7 @@ SELECT
 	p.name,
 	p.description,
 	p.price,
+	p.version,
 	p.created_on,
 	p.created_by,
 	p.updated_on,
@@ -56,15 +58,110 @@ SET
 	name = $3,
 	description = $4,
 	price = $5,
+	version = version + 1,
 	updated_on = $6,
 	updated_by = $7
 WHERE uuid = $1
 	AND company_id = $2
+	AND deleted_on IS NULL
 RETURNING uuid
 `
 
 const deleteProductByUUID = `
+UPDATE product
+SET
+	deleted_on = $3,
+	deleted_by = $4
+WHERE uuid = $1
+	AND company_id = $2
+	AND deleted_on IS NULL
+RETURNING uuid
+`
+
+const restoreProductByUUID = `
+UPDATE product
+SET
+	deleted_on = NULL,
+	deleted_by = NULL,
+	updated_on = $3,
+	updated_by = $4
+WHERE uuid = $1
+	AND company_id = $2
+	AND deleted_on IS NOT NULL
+RETURNING uuid
+`
+
+const purgeDeletedProducts = `
 DELETE FROM product
+WHERE uuid IN (
+	SELECT p.uuid
+	FROM product p
+	WHERE p.deleted_on < $1
+	LIMIT $2
+)
+RETURNING company_id
+`
+
+const insertProductHistory = `
+INSERT INTO product_history (
+	company_id,
+	uuid,
+	product_uuid,
+	operation,
+	changes,
+	created_on,
+	created_by
+)
+values (
+	:company_id,
+	:uuid,
+	:product_uuid,
+	:operation,
+	:changes,
+	:created_on,
+	:created_by
+)
+RETURNING uuid
+`
+
+const findProductViews = `
+SELECT
+	pv.company_id,
+	pv.uuid,
+	pv.name,
+	pv.filters,
+	pv.sorts,
+	pv.created_on,
+	pv.created_by
+FROM product_view pv
+WHERE pv.company_id = $1
+ORDER BY pv.name
+`
+
+const insertProductView = `
+INSERT INTO product_view (
+	company_id,
+	uuid,
+	name,
+	filters,
+	sorts,
+	created_on,
+	created_by
+)
+values (
+	:company_id,
+	:uuid,
+	:name,
+	:filters,
+	:sorts,
+	:created_on,
+	:created_by
+)
+RETURNING uuid
+`
+
+const deleteProductViewByUUID = `
+DELETE FROM product_view
 WHERE uuid = $1 AND company_id = $2
 RETURNING uuid
 `
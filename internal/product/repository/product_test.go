@@ -1,17 +1,22 @@
 package repository_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"log"
 	"mceasy/service-demo/config"
+	"mceasy/service-demo/internal/identity/identityentities"
 	"mceasy/service-demo/internal/product"
 	v1 "mceasy/service-demo/internal/product/delivery/http/external/v1"
 	"mceasy/service-demo/internal/product/dtos"
 	"mceasy/service-demo/internal/product/entities"
 	"mceasy/service-demo/internal/product/repository"
+	"mceasy/service-demo/internal/product/tabledefinition"
+	"mceasy/service-demo/pkg/apperror"
 	"mceasy/service-demo/pkg/database"
 	"mceasy/service-demo/pkg/resourceful"
+	"strings"
 	"testing"
 	"time"
 
@@ -207,6 +212,72 @@ func TestProductRepo_FindProductResourceful(t *testing.T) {
 
 }
 
+func TestProductRepo_GetProductStatsResourceful(t *testing.T) {
+	db, err := database.GetPostgreConnection(cfg)
+	require.NoError(t, err)
+	productRepo := repository.NewProductPGRepo(db)
+
+	t.Run("integration_not_empty", func(t *testing.T) {
+		expectedProduct := entities.Product{
+			CompanyId:   392,
+			UUID:        uuid.NewString(),
+			Name:        "Rinso",
+			Description: "ini deterjen",
+			Price:       1500,
+			CreatedOn:   time.Now(),
+			CreatedBy:   "admin",
+			UpdatedOn:   time.Now(),
+			UpdatedBy:   "admin",
+		}
+
+		_, err := productRepo.StoreNewProduct(context.Background(), expectedProduct)
+		require.NoError(t, err)
+
+		instance := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		err = instance.SetParam(resourceful.Parameter{LocalFilters: []string{"company_id eq 392"}})
+		require.NoError(t, err)
+
+		productStats, err := productRepo.GetProductStatsResourceful(context.Background(), instance)
+		require.NoError(t, err)
+		require.NotZero(t, productStats.Count)
+		require.NotZero(t, productStats.TotalPrice)
+		require.NotZero(t, productStats.MaxPrice)
+	})
+}
+
+func TestProductRepo_ExportProductResourceful(t *testing.T) {
+	db, err := database.GetPostgreConnection(cfg)
+	require.NoError(t, err)
+	productRepo := repository.NewProductPGRepo(db)
+
+	t.Run("integration_csv", func(t *testing.T) {
+		expectedProduct := entities.Product{
+			CompanyId:   392,
+			UUID:        uuid.NewString(),
+			Name:        "Rinso",
+			Description: "ini deterjen",
+			Price:       1500,
+			CreatedOn:   time.Now(),
+			CreatedBy:   "admin",
+			UpdatedOn:   time.Now(),
+			UpdatedBy:   "admin",
+		}
+
+		_, err := productRepo.StoreNewProduct(context.Background(), expectedProduct)
+		require.NoError(t, err)
+
+		instance := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		err = instance.SetParam(resourceful.Parameter{LocalFilters: []string{"company_id eq 392"}})
+		require.NoError(t, err)
+
+		var buffer bytes.Buffer
+		err = productRepo.ExportProductResourceful(context.Background(), instance, &buffer, resourceful.CSV_EXPORT)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(buffer.String(), "uuid,name,description,price\n"))
+		require.Contains(t, buffer.String(), expectedProduct.UUID)
+	})
+}
+
 func TestProductRepo_DeleteProductByUUID(t *testing.T) {
 	db, err := database.GetPostgreConnection(cfg)
 	require.NoError(t, err)
@@ -228,10 +299,61 @@ func TestProductRepo_DeleteProductByUUID(t *testing.T) {
 		_, err := productRepo.StoreNewProduct(context.Background(), expectedProduct)
 		require.NoError(t, err)
 
-		err = productRepo.DeleteProductByUUID(context.Background(), expectedProduct.UUID, expectedProduct.CompanyId)
+		err = productRepo.DeleteProductByUUID(context.Background(), entities.DeleteProduct{
+			CompanyId: expectedProduct.CompanyId,
+			UUID:      expectedProduct.UUID,
+			DeletedOn: time.Now(),
+			DeletedBy: "admin",
+		})
+		require.NoError(t, err)
+
+		_, err = productRepo.GetProductByUUID(context.Background(), expectedProduct.UUID, expectedProduct.CompanyId)
+		require.Error(t, err)
+
+		_, err = productRepo.GetProductByUUID(context.Background(), expectedProduct.UUID, expectedProduct.CompanyId, entities.GetProductOption{IsDeleted: true})
+		require.NoError(t, err)
+
+		err = productRepo.RestoreProductByUUID(context.Background(), entities.RestoreProduct{
+			CompanyId: expectedProduct.CompanyId,
+			UUID:      expectedProduct.UUID,
+			UpdatedOn: time.Now(),
+			UpdatedBy: "admin",
+		})
 		require.NoError(t, err)
 
 		_, err = productRepo.GetProductByUUID(context.Background(), expectedProduct.UUID, expectedProduct.CompanyId)
+		require.NoError(t, err)
+	})
+
+	t.Run("integration_purge", func(t *testing.T) {
+		expectedProduct := entities.Product{
+			CompanyId:   392,
+			UUID:        uuid.NewString(),
+			Name:        "Swallow",
+			Description: "Ini Sandal",
+			Price:       5000,
+			CreatedOn:   time.Now(),
+			CreatedBy:   "admin",
+			UpdatedOn:   time.Now(),
+			UpdatedBy:   "admin",
+		}
+
+		_, err := productRepo.StoreNewProduct(context.Background(), expectedProduct)
+		require.NoError(t, err)
+
+		err = productRepo.DeleteProductByUUID(context.Background(), entities.DeleteProduct{
+			CompanyId: expectedProduct.CompanyId,
+			UUID:      expectedProduct.UUID,
+			DeletedOn: time.Now().Add(-time.Hour),
+			DeletedBy: "admin",
+		})
+		require.NoError(t, err)
+
+		companyIds, err := productRepo.PurgeDeletedProducts(context.Background(), time.Now(), 1000)
+		require.NoError(t, err)
+		require.Contains(t, companyIds, expectedProduct.CompanyId)
+
+		_, err = productRepo.GetProductByUUID(context.Background(), expectedProduct.UUID, expectedProduct.CompanyId, entities.GetProductOption{IsDeleted: true})
 		require.Error(t, err)
 	})
 }
@@ -285,5 +407,84 @@ func TestProductRepo_UpdateProductByUUID(t *testing.T) {
 		require.Equal(t, int64(10000), product.Price)
 		require.Equal(t, expectedProduct.CreatedBy, product.CreatedBy)
 		require.Equal(t, "who !?", product.UpdatedBy)
+		require.Equal(t, int64(2), product.Version)
+	})
+}
+
+func TestProductRepo_ProductHistory(t *testing.T) {
+	db, err := database.GetPostgreConnection(cfg)
+	require.NoError(t, err)
+	productRepo := repository.NewProductPGRepo(db)
+
+	t.Run("integration_store_find", func(t *testing.T) {
+		cred := identityentities.Credential{UserName: "admin", UserId: 1, CompanyId: 392}
+		before := entities.Product{CompanyId: 392, UUID: uuid.NewString(), Name: "Swallow", Description: "Ini Sandal", Price: 5000}
+		after := before
+		after.Price = 7000
+
+		err := productRepo.StoreNewProductHistory(context.Background(), entities.NewProductHistory(cred, entities.ProductHistoryUpdate, &before, &after))
+		require.NoError(t, err)
+
+		instance := resourceful.NewResource[uuid.UUID, dtos.ProductHistoryList](v1.ProductHistoryDefinition)
+		err = instance.Scope(tabledefinition.ProductHistory.Field("product_uuid"), resourceful.EQ, before.UUID)
+		require.NoError(t, err)
+		err = instance.SetParam(resourceful.Parameter{Limit: 10, Page: 1, Filters: []string{"changes.price notnull"}})
+		require.NoError(t, err)
+
+		resourceHistory, err := productRepo.FindProductHistoryResourceful(context.Background(), instance)
+		require.NoError(t, err)
+
+		paginatedResult := resourceHistory.Response().Data.PaginatedResult
+		require.Len(t, paginatedResult, 1)
+		require.Equal(t, entities.ProductHistoryUpdate, *paginatedResult[0].Operation)
+		require.Equal(t, float64(7000), (*paginatedResult[0].Changes)["price"].After)
+	})
+}
+
+func TestProductRepo_ProductView(t *testing.T) {
+	db, err := database.GetPostgreConnection(cfg)
+	require.NoError(t, err)
+	productRepo := repository.NewProductPGRepo(db)
+
+	t.Run("integration_store_find_delete", func(t *testing.T) {
+		expectedProductView := entities.ProductView{
+			CompanyId: 392,
+			UUID:      uuid.NewString(),
+			Name:      "view-" + uuid.NewString(),
+			Filters:   []string{"price lt 1000"},
+			Sorts:     []string{"name asc"},
+			CreatedOn: time.Now(),
+			CreatedBy: "admin",
+		}
+
+		productViewUUID, err := productRepo.StoreNewProductView(context.Background(), expectedProductView)
+		require.NoError(t, err)
+		require.Equal(t, expectedProductView.UUID, productViewUUID)
+
+		duplicateProductView := expectedProductView
+		duplicateProductView.UUID = uuid.NewString()
+		_, err = productRepo.StoreNewProductView(context.Background(), duplicateProductView)
+		require.Equal(t, apperror.BadRequestMap(map[string][]string{
+			"name": {"already exists"},
+		}), err)
+
+		productViews, err := productRepo.FindProductViews(context.Background(), expectedProductView.CompanyId)
+		require.NoError(t, err)
+
+		var found bool
+		for _, productView := range productViews {
+			if productView.UUID == expectedProductView.UUID {
+				found = true
+				require.Equal(t, expectedProductView.Filters, productView.Filters)
+				require.Equal(t, expectedProductView.Sorts, productView.Sorts)
+			}
+		}
+		require.True(t, found)
+
+		err = productRepo.DeleteProductViewByUUID(context.Background(), expectedProductView.UUID, expectedProductView.CompanyId)
+		require.NoError(t, err)
+
+		err = productRepo.DeleteProductViewByUUID(context.Background(), expectedProductView.UUID, expectedProductView.CompanyId)
+		require.Error(t, err)
 	})
 }
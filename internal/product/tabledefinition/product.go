@@ -1,14 +1,61 @@
 package tabledefinition
 
-import "mceasy/service-demo/pkg/resourceful"
+import (
+	"mceasy/service-demo/internal/product/entities"
+	"mceasy/service-demo/pkg/resourceful"
+	"time"
+)
 
+// The search vector is indexed by the product_search_vector_gin_index, keep the index expression
+// in sync with the search fields (weight & language)
 var Product = &resourceful.Table{
 	Name: "product",
 	Fields: []*resourceful.Field{
 		{Name: "company_id", Type: resourceful.NUMERIC, LocalFilterable: true},
 		{Name: "uuid"},
-		{Name: "name", Searchable: true, Sortable: true},
-		{Name: "description", Searchable: true, Sortable: true},
-		{Name: "price", Searchable: true, Sortable: true},
+		{Name: "name", Type: resourceful.STRING, Searchable: true, SearchWeight: "A", Sortable: true, PatternFilterable: true, Selectable: true},
+		{Name: "description", Type: resourceful.STRING, Searchable: true, SearchWeight: "B", Sortable: true, PatternFilterable: true, Selectable: true},
+		{Name: "price", Type: resourceful.NUMERIC, Searchable: true, SearchWeight: "D", Filterable: true, Sortable: true, Selectable: true, Facetable: true, FacetRanges: ProductPriceBands},
+		{Name: "created_by", Type: resourceful.STRING, Facetable: true},
+		{Name: "created_on"},
+		{Name: "updated_on"},
+		{Name: "deleted_on", Type: resourceful.DATE, SoftDeleteField: true},
+		{Name: "last_modified_on", Type: resourceful.DATE, Expression: "coalesce({updated_on}, {created_on})", Filterable: true, Sortable: true, Selectable: true},
 	},
 }
+
+// The product audit history, the changes are filterable by the field path. e.g. changes.price notnull
+var ProductHistory = &resourceful.Table{
+	Name: "product_history",
+	Fields: []*resourceful.Field{
+		{Name: "company_id", Type: resourceful.NUMERIC},
+		{Name: "uuid"},
+		{Name: "product_uuid", Type: resourceful.UUID},
+		{Name: "operation", Type: resourceful.ENUM, Values: []string{entities.ProductHistoryCreate, entities.ProductHistoryUpdate, entities.ProductHistoryDelete, entities.ProductHistoryRestore}, Filterable: true, Selectable: true, Facetable: true},
+		{Name: "changes", Type: resourceful.JSONB, Filterable: true, Selectable: true},
+		{Name: "created_on", Type: resourceful.DATE, Sort: "desc", Filterable: true, Sortable: true, Selectable: true},
+		{Name: "created_by", Type: resourceful.STRING, Searchable: true, Filterable: true, Selectable: true, Facetable: true},
+	},
+}
+
+// The product index views, applied by the view param
+var ProductViews = []resourceful.View{
+	{
+		Name:    "recently_updated_expensive",
+		Filters: []string{"price gte 1000000"},
+		Sorts:   []string{"last_modified_on desc"},
+	},
+}
+
+// The price bands of the product price facet
+var ProductPriceBands = []float64{10000, 50000, 100000, 500000, 1000000}
+
+// The product query cost guardrails
+var ProductPolicy = resourceful.Policy{
+	MaxLimit:         100,
+	MaxFilters:       10,
+	MaxSorts:         3,
+	MinSearchLength:  2,
+	MinPatternLength: 2,
+	StatementTimeout: 5 * time.Second,
+}
@@ -2,18 +2,29 @@ package product
 
 import (
 	"context"
+	"io"
 	"mceasy/service-demo/internal/identity/identityentities"
 	"mceasy/service-demo/internal/product/dtos"
 	"mceasy/service-demo/internal/product/entities"
 	"mceasy/service-demo/pkg/resourceful"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type UseCase interface {
 	Index(ctx context.Context, companyId uint64, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (*resourceful.Resource[uuid.UUID, dtos.ProductList], error)
+	History(ctx context.Context, companyId uint64, productUUID string, resource *resourceful.Resource[uuid.UUID, dtos.ProductHistoryList]) (*resourceful.Resource[uuid.UUID, dtos.ProductHistoryList], error)
+	Stats(ctx context.Context, companyId uint64, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (dtos.ProductStats, error)
+	Export(ctx context.Context, companyId uint64, resource *resourceful.Resource[uuid.UUID, dtos.ProductList], format string) (func(ctx context.Context, w io.Writer) error, error)
 	Store(ctx context.Context, requestCredential identityentities.Credential, payload entities.StoreProduct) (string, error)
 	Show(ctx context.Context, productUUID string, companyId int64) (entities.Product, error)
-	Update(ctx context.Context, requestCredential identityentities.Credential, productUUID string, payload entities.UpdateProduct) error
-	Delete(ctx context.Context, productUUID string, companyId int64) error
+	Update(ctx context.Context, requestCredential identityentities.Credential, productUUID string, payload entities.UpdateProduct) (int64, error)
+	Delete(ctx context.Context, requestCredential identityentities.Credential, productUUID string) error
+	Restore(ctx context.Context, requestCredential identityentities.Credential, productUUID string) error
+	Purge(ctx context.Context, retention time.Duration) (int, error)
+
+	IndexViews(ctx context.Context, companyId int64) ([]dtos.ProductViewResponse, error)
+	StoreView(ctx context.Context, requestCredential identityentities.Credential, payload entities.StoreProductView) (string, error)
+	DeleteView(ctx context.Context, productViewUUID string, companyId int64) error
 }
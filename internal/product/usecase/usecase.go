@@ -4,42 +4,59 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
+	"io"
+	"log"
 	"mceasy/service-demo/internal/identity/identityentities"
 	"mceasy/service-demo/internal/product"
 	"mceasy/service-demo/internal/product/dtos"
 	"mceasy/service-demo/internal/product/entities"
+	"mceasy/service-demo/internal/product/tabledefinition"
 	"mceasy/service-demo/pkg/apperror"
 	"mceasy/service-demo/pkg/observability/instrumentation"
 	"mceasy/service-demo/pkg/resourceful"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Number of the products hard deleted per purge query
+const purgeBatchSize = 1000
+
+var ErrInvalidPurgeRetention = errors.New("purge retention must be positive")
+
 type UseCaseParameter struct {
-	ProductRepo product.Repository
+	ProductRepo       product.Repository
+	ProductDefinition *resourceful.Definition
+	// Optional, the product Index result is cached per company
+	ProductCache *resourceful.Cache[uuid.UUID, dtos.ProductList]
 }
 
 func NewProductUseCase(param UseCaseParameter) product.UseCase {
 	return &productUC{
-		repo: param.ProductRepo,
+		repo:       param.ProductRepo,
+		definition: param.ProductDefinition,
+		cache:      param.ProductCache,
 	}
 }
 
 type productUC struct {
-	repo product.Repository
+	repo       product.Repository
+	definition *resourceful.Definition
+	cache      *resourceful.Cache[uuid.UUID, dtos.ProductList]
 }
 
-func (u *productUC) Update(ctx context.Context, requestCredential identityentities.Credential, productUUID string, payload entities.UpdateProduct) error {
+// Update the product & get the new version of the product
+func (u *productUC) Update(ctx context.Context, requestCredential identityentities.Credential, productUUID string, payload entities.UpdateProduct) (int64, error) {
 	ctx, span := instrumentation.NewTraceSpan(
 		ctx,
 		"UpdateUseCase",
 	)
 	defer span.End()
 
-	return u.repo.Atomic(ctx, &sql.TxOptions{}, func(tx product.Repository) error {
+	var version int64
+	err := u.repo.Atomic(ctx, &sql.TxOptions{}, func(tx product.Repository) error {
 		productEntity, err := tx.GetProductByUUID(ctx, productUUID, int64(requestCredential.CompanyId), entities.GetProductOption{
 			PessimisticLocking: true,
 		})
@@ -47,6 +64,11 @@ func (u *productUC) Update(ctx context.Context, requestCredential identityentiti
 			return err
 		}
 
+		// The version is compared on the locked product, the editor of the outdated version must reload the product
+		if payload.Version != 0 && payload.Version != productEntity.Version {
+			return apperror.Conflict()
+		}
+
 		exists, err := tx.IsProductKeyExists(ctx, entities.StoreProduct{
 			Name:        payload.Name,
 			Description: payload.Description,
@@ -62,7 +84,7 @@ func (u *productUC) Update(ctx context.Context, requestCredential identityentiti
 			})
 		}
 
-		err = tx.UpdateProductByUUID(ctx, entities.UpdateProduct{
+		updateProduct := entities.UpdateProduct{
 			CompanyId:   int64(requestCredential.CompanyId),
 			UUID:        productUUID,
 			Name:        payload.Name,
@@ -70,30 +92,165 @@ func (u *productUC) Update(ctx context.Context, requestCredential identityentiti
 			Price:       payload.Price,
 			UpdatedOn:   time.Now(),
 			UpdatedBy:   requestCredential.UserName,
-		})
+		}
+		err = tx.UpdateProductByUUID(ctx, updateProduct)
 		if err != nil {
 			return err
 		}
+		// The product is locked, the update query increments the locked version
+		version = productEntity.Version + 1
 
-		return nil
+		updatedProductEntity := productEntity
+		updatedProductEntity.Name = updateProduct.Name
+		updatedProductEntity.Description = updateProduct.Description
+		updatedProductEntity.Price = updateProduct.Price
+		updatedProductEntity.UpdatedOn = updateProduct.UpdatedOn
+		updatedProductEntity.UpdatedBy = updateProduct.UpdatedBy
+
+		// The update without any changed field has no history
+		productHistory := entities.NewProductHistory(requestCredential, entities.ProductHistoryUpdate, &productEntity, &updatedProductEntity)
+		if len(productHistory.Changes) == 0 {
+			return nil
+		}
+
+		return tx.StoreNewProductHistory(ctx, productHistory)
 	})
+	if err != nil {
+		return 0, err
+	}
+
+	u.invalidateCache(ctx, requestCredential.CompanyId)
+
+	return version, nil
 }
 
-func (u *productUC) Delete(ctx context.Context, productUUID string, companyId int64) error {
+func (u *productUC) Delete(ctx context.Context, requestCredential identityentities.Credential, productUUID string) error {
 	ctx, span := instrumentation.NewTraceSpan(
 		ctx,
 		"DeleteUseCase",
 	)
 	defer span.End()
 
-	err := u.repo.DeleteProductByUUID(ctx, productUUID, companyId)
+	err := u.repo.Atomic(ctx, &sql.TxOptions{}, func(tx product.Repository) error {
+		productEntity, err := tx.GetProductByUUID(ctx, productUUID, int64(requestCredential.CompanyId), entities.GetProductOption{
+			PessimisticLocking: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = tx.DeleteProductByUUID(ctx, entities.DeleteProduct{
+			CompanyId: int64(requestCredential.CompanyId),
+			UUID:      productUUID,
+			DeletedOn: time.Now(),
+			DeletedBy: requestCredential.UserName,
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.StoreNewProductHistory(ctx, entities.NewProductHistory(requestCredential, entities.ProductHistoryDelete, &productEntity, nil))
+	})
 	if err != nil {
 		return err
 	}
 
+	u.invalidateCache(ctx, requestCredential.CompanyId)
+
 	return nil
 }
 
+// Restore the soft deleted product, the name must not be taken by the other product since it was deleted
+func (u *productUC) Restore(ctx context.Context, requestCredential identityentities.Credential, productUUID string) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"RestoreUseCase",
+	)
+	defer span.End()
+
+	err := u.repo.Atomic(ctx, &sql.TxOptions{}, func(tx product.Repository) error {
+		productEntity, err := tx.GetProductByUUID(ctx, productUUID, int64(requestCredential.CompanyId), entities.GetProductOption{
+			PessimisticLocking: true,
+			IsDeleted:          true,
+		})
+		if err != nil {
+			return err
+		}
+
+		exists, err := tx.IsProductKeyExists(ctx, entities.StoreProduct{
+			Name:        productEntity.Name,
+			Description: productEntity.Description,
+			Price:       productEntity.Price,
+		}, int64(requestCredential.CompanyId))
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return apperror.BadRequestMap(map[string][]string{
+				"product": {"already exists"},
+			})
+		}
+
+		err = tx.RestoreProductByUUID(ctx, entities.RestoreProduct{
+			CompanyId: int64(requestCredential.CompanyId),
+			UUID:      productUUID,
+			UpdatedOn: time.Now(),
+			UpdatedBy: requestCredential.UserName,
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.StoreNewProductHistory(ctx, entities.NewProductHistory(requestCredential, entities.ProductHistoryRestore, nil, &productEntity))
+	})
+	if err != nil {
+		return err
+	}
+
+	u.invalidateCache(ctx, requestCredential.CompanyId)
+
+	return nil
+}
+
+// Hard delete the products soft deleted longer than the retention in batches, return the number of the purged products
+func (u *productUC) Purge(ctx context.Context, retention time.Duration) (int, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"PurgeUseCase",
+	)
+	defer span.End()
+
+	// Guard the zero retention, every soft deleted product would be purged
+	if retention <= 0 {
+		return 0, ErrInvalidPurgeRetention
+	}
+
+	deletedBefore := time.Now().Add(-retention)
+
+	var purgedCount int
+	for {
+		companyIds, err := u.repo.PurgeDeletedProducts(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			return purgedCount, err
+		}
+		purgedCount += len(companyIds)
+
+		// The purged products are still listed by the include_deleted & only_deleted Index
+		invalidatedCompanyIds := make(map[int64]bool)
+		for _, companyId := range companyIds {
+			if !invalidatedCompanyIds[companyId] {
+				invalidatedCompanyIds[companyId] = true
+				u.invalidateCache(ctx, uint64(companyId))
+			}
+		}
+
+		if len(companyIds) < purgeBatchSize {
+			return purgedCount, nil
+		}
+	}
+}
+
 func (u *productUC) Index(ctx context.Context, companyId uint64, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (*resourceful.Resource[uuid.UUID, dtos.ProductList], error) {
 	ctx, span := instrumentation.NewTraceSpan(
 		ctx,
@@ -101,16 +258,31 @@ func (u *productUC) Index(ctx context.Context, companyId uint64, resource *resou
 	)
 	defer span.End()
 
-	resource.Parameter.LocalFilters = append(
-		resource.Parameter.LocalFilters,
-		fmt.Sprintf("company_id eq %d", companyId),
-	)
+	err := resource.Scope(tabledefinition.Product.Field("company_id"), resourceful.EQ, companyId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.addCompanyView(ctx, companyId, resource)
+	if err != nil {
+		return nil, err
+	}
 
-	err := resource.SetParam(*resource.Parameter)
+	err = resource.SetParam(*resource.Parameter)
 	if err != nil {
 		return nil, err
 	}
 
+	if u.cache != nil {
+		ok, err := u.cache.Get(ctx, strconv.FormatUint(companyId, 10), resource)
+		if err != nil {
+			log.Println(err)
+		}
+		if ok {
+			return resource, nil
+		}
+	}
+
 	productResource, err := u.repo.FindProductResourceful(ctx, resource)
 	if err != nil {
 		if errors.Is(err, resourceful.ErrPagination) {
@@ -119,32 +291,139 @@ func (u *productUC) Index(ctx context.Context, companyId uint64, resource *resou
 		return nil, err
 	}
 
+	if u.cache != nil {
+		err = u.cache.Set(ctx, strconv.FormatUint(companyId, 10), productResource)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
 	return productResource, nil
 }
 
-func (u *productUC) Store(ctx context.Context, requestCredential identityentities.Credential, payload entities.StoreProduct) (string, error) {
+// List the audit history of the product, the history of the deleted & purged product is still listed
+func (u *productUC) History(ctx context.Context, companyId uint64, productUUID string, resource *resourceful.Resource[uuid.UUID, dtos.ProductHistoryList]) (*resourceful.Resource[uuid.UUID, dtos.ProductHistoryList], error) {
 	ctx, span := instrumentation.NewTraceSpan(
 		ctx,
-		"StoreUseCase",
+		"HistoryUseCase",
 	)
 	defer span.End()
 
-	exists, err := u.repo.IsProductKeyExists(ctx, payload, int64(requestCredential.CompanyId))
+	err := resource.Scope(tabledefinition.ProductHistory.Field("company_id"), resourceful.EQ, companyId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if exists {
-		return "", apperror.BadRequestMap(map[string][]string{
-			"product": {"already exists"},
-		})
+	err = resource.Scope(tabledefinition.ProductHistory.Field("product_uuid"), resourceful.EQ, productUUID)
+	if err != nil {
+		return nil, err
 	}
 
-	productUUID, err := u.repo.StoreNewProduct(ctx, entities.NewProduct(requestCredential, payload))
+	err = resource.SetParam(*resource.Parameter)
+	if err != nil {
+		return nil, err
+	}
+
+	historyResource, err := u.repo.FindProductHistoryResourceful(ctx, resource)
+	if err != nil {
+		if errors.Is(err, resourceful.ErrPagination) {
+			return resource, nil
+		}
+		return nil, err
+	}
+
+	return historyResource, nil
+}
+
+func (u *productUC) Stats(ctx context.Context, companyId uint64, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (dtos.ProductStats, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"StatsUseCase",
+	)
+	defer span.End()
+
+	err := resource.Scope(tabledefinition.Product.Field("company_id"), resourceful.EQ, companyId)
+	if err != nil {
+		return dtos.ProductStats{}, err
+	}
+
+	err = resource.SetParam(*resource.Parameter)
+	if err != nil {
+		return dtos.ProductStats{}, err
+	}
+
+	productStats, err := u.repo.GetProductStatsResourceful(ctx, resource)
+	if err != nil {
+		return dtos.ProductStats{}, err
+	}
+
+	return productStats, nil
+}
+
+// The export param is validated before the stream function is returned, so the validation error is
+// reported before the response is streamed
+func (u *productUC) Export(ctx context.Context, companyId uint64, resource *resourceful.Resource[uuid.UUID, dtos.ProductList], format string) (func(ctx context.Context, w io.Writer) error, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"ExportUseCase",
+	)
+	defer span.End()
+
+	err := resource.Scope(tabledefinition.Product.Field("company_id"), resourceful.EQ, companyId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.addCompanyView(ctx, companyId, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resource.SetParam(*resource.Parameter)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stream runs after the response has started, it gets the context of the response stream
+	return func(ctx context.Context, w io.Writer) error {
+		return u.repo.ExportProductResourceful(ctx, resource, w, format)
+	}, nil
+}
+
+func (u *productUC) Store(ctx context.Context, requestCredential identityentities.Credential, payload entities.StoreProduct) (string, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"StoreUseCase",
+	)
+	defer span.End()
+
+	var productUUID string
+	err := u.repo.Atomic(ctx, &sql.TxOptions{}, func(tx product.Repository) error {
+		exists, err := tx.IsProductKeyExists(ctx, payload, int64(requestCredential.CompanyId))
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return apperror.BadRequestMap(map[string][]string{
+				"product": {"already exists"},
+			})
+		}
+
+		productEntity := entities.NewProduct(requestCredential, payload)
+		productUUID, err = tx.StoreNewProduct(ctx, productEntity)
+		if err != nil {
+			return err
+		}
+
+		return tx.StoreNewProductHistory(ctx, entities.NewProductHistory(requestCredential, entities.ProductHistoryCreate, nil, &productEntity))
+	})
 	if err != nil {
 		return "", err
 	}
 
+	u.invalidateCache(ctx, requestCredential.CompanyId)
+
 	return productUUID, nil
 }
 
@@ -162,3 +441,116 @@ func (uc *productUC) Show(ctx context.Context, productUUID string, companyId int
 
 	return product, nil
 }
+
+func (u *productUC) IndexViews(ctx context.Context, companyId int64) ([]dtos.ProductViewResponse, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"IndexViewsUseCase",
+	)
+	defer span.End()
+
+	productViews, err := u.repo.FindProductViews(ctx, companyId)
+	if err != nil {
+		return nil, err
+	}
+
+	// The definition views are listed first
+	response := make([]dtos.ProductViewResponse, 0)
+	for _, view := range u.definition.Views() {
+		response = append(response, dtos.NewDefaultProductViewResponse(view))
+	}
+	for _, productView := range productViews {
+		response = append(response, dtos.NewProductViewResponse(productView))
+	}
+
+	return response, nil
+}
+
+func (u *productUC) StoreView(ctx context.Context, requestCredential identityentities.Credential, payload entities.StoreProductView) (string, error) {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"StoreViewUseCase",
+	)
+	defer span.End()
+
+	productViews, err := u.repo.FindProductViews(ctx, int64(requestCredential.CompanyId))
+	if err != nil {
+		return "", err
+	}
+
+	exists := u.definition.HasView(payload.Name)
+	for _, productView := range productViews {
+		if productView.Name == payload.Name {
+			exists = true
+		}
+	}
+
+	if exists {
+		return "", apperror.BadRequestMap(map[string][]string{
+			"name": {"already exists"},
+		})
+	}
+
+	productView := entities.NewProductView(requestCredential, payload)
+
+	// The view filters & sorts must be valid on the product definition
+	err = u.definition.ValidateView(productView.View())
+	if err != nil {
+		return "", err
+	}
+
+	productViewUUID, err := u.repo.StoreNewProductView(ctx, productView)
+	if err != nil {
+		return "", err
+	}
+
+	return productViewUUID, nil
+}
+
+func (u *productUC) DeleteView(ctx context.Context, productViewUUID string, companyId int64) error {
+	ctx, span := instrumentation.NewTraceSpan(
+		ctx,
+		"DeleteViewUseCase",
+	)
+	defer span.End()
+
+	err := u.repo.DeleteProductViewByUUID(ctx, productViewUUID, companyId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// The view other than the definition view is the company saved view, the unknown view is reported by SetParam
+func (u *productUC) addCompanyView(ctx context.Context, companyId uint64, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) error {
+	view := resource.Parameter.View
+	if view == "" || resource.HasView(view) {
+		return nil
+	}
+
+	productViews, err := u.repo.FindProductViews(ctx, int64(companyId))
+	if err != nil {
+		return err
+	}
+
+	for _, productView := range productViews {
+		if productView.Name == view {
+			return resource.AddView(productView.View())
+		}
+	}
+
+	return nil
+}
+
+// The cache error is only logged, the product is already written & the cached result expires by the TTL
+func (u *productUC) invalidateCache(ctx context.Context, companyId uint64) {
+	if u.cache == nil {
+		return
+	}
+
+	err := u.cache.Invalidate(ctx, strconv.FormatUint(companyId, 10))
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -1,17 +1,24 @@
 package usecase_test
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
 	"mceasy/service-demo/internal/identity/identityentities"
+	"mceasy/service-demo/internal/product"
 	v1 "mceasy/service-demo/internal/product/delivery/http/external/v1"
 	"mceasy/service-demo/internal/product/dtos"
 	"mceasy/service-demo/internal/product/entities"
 	mocks "mceasy/service-demo/internal/product/mock"
+	"mceasy/service-demo/internal/product/tabledefinition"
 	"mceasy/service-demo/internal/product/usecase"
+	"mceasy/service-demo/pkg/apperror"
 	"mceasy/service-demo/pkg/observability/instrumentation"
 	"mceasy/service-demo/pkg/resourceful"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -68,31 +75,30 @@ func TestProductUseCase_Index(t *testing.T) {
 	)
 
 	expectedResourceful := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
-	err := expectedResourceful.SetParam(resourceful.Parameter{
+	err := expectedResourceful.Scope(tabledefinition.Product.Field("company_id"), resourceful.EQ, uint64(392))
+	require.NoError(t, err)
+	err = expectedResourceful.SetParam(resourceful.Parameter{
 		Limit: 10,
 		Page:  1,
-		LocalFilters: []string{
-			"company_id eq 392",
-		},
 	})
 	require.NoError(t, err)
 
 	returnedResourceful := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	err = returnedResourceful.Scope(tabledefinition.Product.Field("company_id"), resourceful.EQ, uint64(392))
+	require.NoError(t, err)
 	err = returnedResourceful.SetParam(resourceful.Parameter{
 		Limit: 10,
 		Page:  1,
-		LocalFilters: []string{
-			"company_id eq 392",
-		},
 	})
 	require.NoError(t, err)
 
+	name, description := "Kacang", "Ini Kacang"
 	returnedResourceful.SetResult(resourceful.Result[uuid.UUID, dtos.ProductList]{
 		Ids: []uuid.UUID{uuid.New()},
 		PaginatedResult: []dtos.ProductList{
 			{
-				Name:        "Kacang",
-				Description: "Ini Kacang",
+				Name:        &name,
+				Description: &description,
 			},
 		},
 	})
@@ -122,6 +128,234 @@ func TestProductUseCase_Index(t *testing.T) {
 	assert.NotEqual(t, resourcefulProduct, resourceProduct)
 }
 
+func TestProductUseCase_Index_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	name := "Kacang"
+	productId := uuid.New()
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+
+	// The second Index is loaded from the cache, the Index after the Delete is loaded from the repo
+	mockProductRepo.
+		EXPECT().
+		FindProductResourceful(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductList]) (*resourceful.Resource[uuid.UUID, dtos.ProductList], error) {
+			resource.SetResult(resourceful.Result[uuid.UUID, dtos.ProductList]{
+				Ids:             []uuid.UUID{productId},
+				PaginatedResult: []dtos.ProductList{{UUID: productId.String(), Name: &name}},
+			})
+			return resource, nil
+		}).
+		Times(2)
+
+	expectAtomic(mockProductRepo)
+
+	mockProductRepo.
+		EXPECT().
+		GetProductByUUID(gomock.Any(), productId.String(), int64(392), gomock.Any()).
+		Return(entities.Product{CompanyId: 392, UUID: productId.String(), Name: name}, nil)
+
+	mockProductRepo.
+		EXPECT().
+		DeleteProductByUUID(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockProductRepo.
+		EXPECT().
+		StoreNewProductHistory(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	productUC := usecase.NewProductUseCase(
+		usecase.UseCaseParameter{
+			ProductRepo:  mockProductRepo,
+			ProductCache: resourceful.NewCache[uuid.UUID, dtos.ProductList](resourceful.NewLRUCacheStore(10), v1.ProductDefinition, time.Minute),
+		},
+	)
+
+	index := func() *resourceful.Response[uuid.UUID, dtos.ProductList] {
+		resourceProduct := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+		resourceProduct.Parameter = &resourceful.Parameter{
+			Limit: 10,
+			Page:  1,
+		}
+
+		resourcefulProduct, err := productUC.Index(context.Background(), 392, resourceProduct)
+		require.NoError(t, err)
+
+		return resourcefulProduct.Response()
+	}
+
+	response := index()
+	assert.Equal(t, response, index())
+
+	err := productUC.Delete(context.Background(), identityentities.Credential{UserName: "admin", UserId: 1, CompanyId: 392}, productId.String())
+	require.NoError(t, err)
+
+	assert.Equal(t, response, index())
+}
+
+func TestProductUseCase_Index_InvalidSearchTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+
+	productUC := usecase.NewProductUseCase(
+		usecase.UseCaseParameter{
+			ProductRepo: mockProductRepo,
+		},
+	)
+
+	resourceProduct := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	resourceProduct.Parameter = &resourceful.Parameter{
+		Limit:  10,
+		Page:   1,
+		Search: "kacang price:murah",
+	}
+
+	_, err := productUC.Index(context.Background(), 392, resourceProduct)
+
+	var validationErrors resourceful.ValidationErrors
+	require.ErrorAs(t, err, &validationErrors)
+	assert.Equal(t, "search", validationErrors[0].FieldName)
+}
+
+func TestProductUseCase_Index_Policy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+
+	productUC := usecase.NewProductUseCase(
+		usecase.UseCaseParameter{
+			ProductRepo: mockProductRepo,
+		},
+	)
+
+	resourceProduct := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	resourceProduct.Parameter = &resourceful.Parameter{
+		Limit:  1000000,
+		Page:   1,
+		Search: "k",
+	}
+
+	_, err := productUC.Index(context.Background(), 392, resourceProduct)
+
+	var validationErrors resourceful.ValidationErrors
+	require.ErrorAs(t, err, &validationErrors)
+	assert.Equal(t, resourceful.ValidationErrors{
+		{FieldName: "limit", Errors: []string{"must be no greater than 100"}},
+		{FieldName: "search", Errors: []string{"must be at least 2 characters"}},
+	}, validationErrors)
+}
+
+func TestProductUseCase_Stats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedCtx, _ := instrumentation.NewTraceSpan(
+		ctx,
+		"StatsUseCase",
+	)
+
+	expectedResourceful := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	err := expectedResourceful.Scope(tabledefinition.Product.Field("company_id"), resourceful.EQ, uint64(392))
+	require.NoError(t, err)
+	err = expectedResourceful.SetParam(resourceful.Parameter{
+		Search: "kacang",
+	})
+	require.NoError(t, err)
+
+	expectedStats := dtos.ProductStats{Count: 2, TotalPrice: 3000, AveragePrice: 1500, MinPrice: 1000, MaxPrice: 2000}
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+
+	mockProductRepo.
+		EXPECT().
+		GetProductStatsResourceful(expectedCtx, expectedResourceful).
+		Return(expectedStats, nil)
+
+	productUC := usecase.NewProductUseCase(
+		usecase.UseCaseParameter{
+			ProductRepo: mockProductRepo,
+		},
+	)
+
+	resourceProduct := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	resourceProduct.Parameter = &resourceful.Parameter{
+		Search: "kacang",
+	}
+
+	productStats, err := productUC.Stats(ctx, 392, resourceProduct)
+	require.NoError(t, err)
+	assert.Equal(t, expectedStats, productStats)
+}
+
+func TestProductUseCase_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	expectedResourceful := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	err := expectedResourceful.Scope(tabledefinition.Product.Field("company_id"), resourceful.EQ, uint64(392))
+	require.NoError(t, err)
+	err = expectedResourceful.SetParam(resourceful.Parameter{
+		Search: "kacang",
+		Fields: []string{"name"},
+	})
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+
+	mockProductRepo.
+		EXPECT().
+		ExportProductResourceful(streamCtx, expectedResourceful, &buffer, resourceful.CSV_EXPORT).
+		Return(nil)
+
+	productUC := usecase.NewProductUseCase(
+		usecase.UseCaseParameter{
+			ProductRepo: mockProductRepo,
+		},
+	)
+
+	resourceProduct := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	resourceProduct.Parameter = &resourceful.Parameter{
+		Search: "kacang",
+		Fields: []string{"name"},
+	}
+
+	stream, err := productUC.Export(ctx, 392, resourceProduct, resourceful.CSV_EXPORT)
+	require.NoError(t, err)
+	require.NoError(t, stream(streamCtx, &buffer))
+}
+
+func TestProductUseCase_Export_InvalidParam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productUC := usecase.NewProductUseCase(
+		usecase.UseCaseParameter{
+			ProductRepo: mocks.NewMockRepository(ctrl),
+		},
+	)
+
+	resourceProduct := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	resourceProduct.Parameter = &resourceful.Parameter{
+		Fields: []string{"random"},
+	}
+
+	_, err := productUC.Export(context.Background(), 392, resourceProduct, resourceful.CSV_EXPORT)
+	require.Error(t, err)
+}
+
 func TestProductUseCase_Store(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -148,6 +382,8 @@ func TestProductUseCase_Store(t *testing.T) {
 
 	mockProductRepo := mocks.NewMockRepository(ctrl)
 
+	expectAtomic(mockProductRepo)
+
 	mockProductRepo.
 		EXPECT().
 		IsProductKeyExists(
@@ -156,6 +392,20 @@ func TestProductUseCase_Store(t *testing.T) {
 			int64(392),
 		).Return(false, nil)
 
+	mockProductRepo.
+		EXPECT().
+		StoreNewProductHistory(expectedCtx, createProductHistoryMatcher(entities.ProductHistory{
+			CompanyId: 392,
+			Operation: entities.ProductHistoryCreate,
+			Changes: entities.ProductChanges{
+				"name":        {Before: nil, After: storeProduct.Name},
+				"description": {Before: nil, After: storeProduct.Description},
+				"price":       {Before: nil, After: storeProduct.Price},
+			},
+			CreatedBy: expectedCred.UserName,
+		})).
+		Return(nil)
+
 	mockProductRepo.
 		EXPECT().
 		StoreNewProduct(
@@ -187,24 +437,598 @@ func TestProductUseCase_Delete(t *testing.T) {
 		"DeleteUseCase",
 	)
 
+	expectedCred := identityentities.Credential{
+		UserName:  "admin",
+		UserId:    1,
+		CompanyId: 392,
+	}
+
 	expectedProductUUID := uuid.NewString()
 
+	deletedProduct := entities.Product{
+		CompanyId:   392,
+		UUID:        expectedProductUUID,
+		Name:        "Mie Indomie",
+		Description: "Mi enak!",
+		Price:       2500,
+	}
+
 	mockProductRepo := mocks.NewMockRepository(ctrl)
 
+	expectAtomic(mockProductRepo)
+
+	mockProductRepo.
+		EXPECT().
+		GetProductByUUID(expectedCtx, expectedProductUUID, int64(392), entities.GetProductOption{PessimisticLocking: true}).
+		Return(deletedProduct, nil)
+
+	mockProductRepo.
+		EXPECT().
+		StoreNewProductHistory(expectedCtx, createProductHistoryMatcher(entities.ProductHistory{
+			CompanyId:   392,
+			ProductUUID: expectedProductUUID,
+			Operation:   entities.ProductHistoryDelete,
+			Changes: entities.ProductChanges{
+				"name":        {Before: deletedProduct.Name, After: nil},
+				"description": {Before: deletedProduct.Description, After: nil},
+				"price":       {Before: deletedProduct.Price, After: nil},
+			},
+			CreatedBy: expectedCred.UserName,
+		})).
+		Return(nil)
+
 	mockProductRepo.
 		EXPECT().
 		DeleteProductByUUID(
 			expectedCtx,
-			expectedProductUUID,
-			int64(392),
+			createDeleteProductMatcher(entities.DeleteProduct{
+				CompanyId: 392,
+				UUID:      expectedProductUUID,
+				DeletedBy: expectedCred.UserName,
+			}),
 		).Return(nil)
 
 	productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
 		ProductRepo: mockProductRepo,
 	})
 
-	err := productUC.Delete(ctx, expectedProductUUID, 392)
+	err := productUC.Delete(ctx, expectedCred, expectedProductUUID)
+	require.NoError(t, err)
+}
+
+func TestProductUseCase_Restore(t *testing.T) {
+	expectedCred := identityentities.Credential{
+		UserName:  "admin",
+		UserId:    1,
+		CompanyId: 392,
+	}
+
+	deletedProduct := entities.Product{
+		CompanyId:   392,
+		UUID:        uuid.NewString(),
+		Name:        "Mie Indomie",
+		Description: "Mi enak!",
+		Price:       2500,
+	}
+
+	newRepo := func(ctrl *gomock.Controller, exists bool) *mocks.MockRepository {
+		mockProductRepo := mocks.NewMockRepository(ctrl)
+
+		expectAtomic(mockProductRepo)
+
+		mockProductRepo.
+			EXPECT().
+			GetProductByUUID(gomock.Any(), deletedProduct.UUID, int64(392), entities.GetProductOption{
+				PessimisticLocking: true,
+				IsDeleted:          true,
+			}).Return(deletedProduct, nil)
+
+		mockProductRepo.
+			EXPECT().
+			IsProductKeyExists(gomock.Any(), entities.StoreProduct{
+				Name:        deletedProduct.Name,
+				Description: deletedProduct.Description,
+				Price:       deletedProduct.Price,
+			}, int64(392)).Return(exists, nil)
+
+		return mockProductRepo
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProductRepo := newRepo(ctrl, false)
+		mockProductRepo.
+			EXPECT().
+			RestoreProductByUUID(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, restoreProduct entities.RestoreProduct) error {
+				assert.Equal(t, int64(392), restoreProduct.CompanyId)
+				assert.Equal(t, deletedProduct.UUID, restoreProduct.UUID)
+				assert.Equal(t, expectedCred.UserName, restoreProduct.UpdatedBy)
+				return nil
+			})
+		mockProductRepo.
+			EXPECT().
+			StoreNewProductHistory(gomock.Any(), createProductHistoryMatcher(entities.ProductHistory{
+				CompanyId:   392,
+				ProductUUID: deletedProduct.UUID,
+				Operation:   entities.ProductHistoryRestore,
+				Changes: entities.ProductChanges{
+					"name":        {Before: nil, After: deletedProduct.Name},
+					"description": {Before: nil, After: deletedProduct.Description},
+					"price":       {Before: nil, After: deletedProduct.Price},
+				},
+				CreatedBy: expectedCred.UserName,
+			})).
+			Return(nil)
+
+		productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+			ProductRepo: mockProductRepo,
+		})
+
+		err := productUC.Restore(context.Background(), expectedCred, deletedProduct.UUID)
+		require.NoError(t, err)
+	})
+
+	t.Run("error if the name is taken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+			ProductRepo: newRepo(ctrl, true),
+		})
+
+		err := productUC.Restore(context.Background(), expectedCred, deletedProduct.UUID)
+		require.Error(t, err)
+	})
+}
+
+func TestProductUseCase_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+
+	// The full batch is followed by the next batch
+	fullBatch := make([]int64, 1000)
+	for i := range fullBatch {
+		fullBatch[i] = 392
+	}
+	gomock.InOrder(
+		mockProductRepo.EXPECT().PurgeDeletedProducts(gomock.Any(), gomock.Any(), 1000).Return(fullBatch, nil),
+		mockProductRepo.EXPECT().PurgeDeletedProducts(gomock.Any(), gomock.Any(), 1000).
+			DoAndReturn(func(ctx context.Context, deletedBefore time.Time, limit int) ([]int64, error) {
+				assert.WithinDuration(t, time.Now().Add(-720*time.Hour), deletedBefore, time.Minute)
+				return []int64{393}, nil
+			}),
+	)
+
+	productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+		ProductRepo: mockProductRepo,
+	})
+
+	purgedCount, err := productUC.Purge(context.Background(), 720*time.Hour)
 	require.NoError(t, err)
+	require.Equal(t, 1001, purgedCount)
+
+	// The repo is not called without the retention
+	purgedCount, err = productUC.Purge(context.Background(), 0)
+	require.ErrorIs(t, err, usecase.ErrInvalidPurgeRetention)
+	require.Equal(t, 0, purgedCount)
+}
+
+func TestProductUseCase_Update(t *testing.T) {
+	expectedCred := identityentities.Credential{
+		UserName:  "admin",
+		UserId:    1,
+		CompanyId: 392,
+	}
+
+	existingProduct := entities.Product{
+		CompanyId:   392,
+		UUID:        uuid.NewString(),
+		Name:        "Mie Indomie",
+		Description: "Mi enak!",
+		Price:       2500,
+		Version:     3,
+	}
+
+	newRepo := func(ctrl *gomock.Controller) *mocks.MockRepository {
+		mockProductRepo := mocks.NewMockRepository(ctrl)
+
+		expectAtomic(mockProductRepo)
+
+		mockProductRepo.
+			EXPECT().
+			GetProductByUUID(gomock.Any(), existingProduct.UUID, int64(392), entities.GetProductOption{PessimisticLocking: true}).
+			Return(existingProduct, nil)
+
+		mockProductRepo.
+			EXPECT().
+			IsProductKeyExists(gomock.Any(), gomock.Any(), int64(392)).
+			Return(true, nil)
+
+		mockProductRepo.
+			EXPECT().
+			UpdateProductByUUID(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		return mockProductRepo
+	}
+
+	t.Run("history of the changed fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProductRepo := newRepo(ctrl)
+		mockProductRepo.
+			EXPECT().
+			StoreNewProductHistory(gomock.Any(), createProductHistoryMatcher(entities.ProductHistory{
+				CompanyId:   392,
+				ProductUUID: existingProduct.UUID,
+				Operation:   entities.ProductHistoryUpdate,
+				Changes: entities.ProductChanges{
+					"price": {Before: existingProduct.Price, After: int64(3000)},
+				},
+				CreatedBy: expectedCred.UserName,
+			})).
+			Return(nil)
+
+		productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+			ProductRepo: mockProductRepo,
+		})
+
+		version, err := productUC.Update(context.Background(), expectedCred, existingProduct.UUID, entities.UpdateProduct{
+			Name:        existingProduct.Name,
+			Description: existingProduct.Description,
+			Price:       3000,
+		})
+		require.NoError(t, err)
+		require.Equal(t, existingProduct.Version+1, version)
+	})
+
+	t.Run("no history without the changed field", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+			ProductRepo: newRepo(ctrl),
+		})
+
+		version, err := productUC.Update(context.Background(), expectedCred, existingProduct.UUID, entities.UpdateProduct{
+			Name:        existingProduct.Name,
+			Description: existingProduct.Description,
+			Price:       existingProduct.Price,
+		})
+		require.NoError(t, err)
+		require.Equal(t, existingProduct.Version+1, version)
+	})
+
+	t.Run("update the matched version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProductRepo := mocks.NewMockRepository(ctrl)
+
+		expectAtomic(mockProductRepo)
+
+		mockProductRepo.
+			EXPECT().
+			GetProductByUUID(gomock.Any(), existingProduct.UUID, int64(392), entities.GetProductOption{PessimisticLocking: true}).
+			Return(existingProduct, nil)
+
+		mockProductRepo.
+			EXPECT().
+			IsProductKeyExists(gomock.Any(), gomock.Any(), int64(392)).
+			Return(true, nil)
+
+		mockProductRepo.
+			EXPECT().
+			UpdateProductByUUID(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		mockProductRepo.
+			EXPECT().
+			StoreNewProductHistory(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+			ProductRepo: mockProductRepo,
+		})
+
+		version, err := productUC.Update(context.Background(), expectedCred, existingProduct.UUID, entities.UpdateProduct{
+			Name:        existingProduct.Name,
+			Description: existingProduct.Description,
+			Price:       3000,
+			Version:     existingProduct.Version,
+		})
+		require.NoError(t, err)
+		require.Equal(t, existingProduct.Version+1, version)
+	})
+
+	t.Run("error if the version is outdated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProductRepo := mocks.NewMockRepository(ctrl)
+
+		expectAtomic(mockProductRepo)
+
+		mockProductRepo.
+			EXPECT().
+			GetProductByUUID(gomock.Any(), existingProduct.UUID, int64(392), entities.GetProductOption{PessimisticLocking: true}).
+			Return(existingProduct, nil)
+
+		productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+			ProductRepo: mockProductRepo,
+		})
+
+		version, err := productUC.Update(context.Background(), expectedCred, existingProduct.UUID, entities.UpdateProduct{
+			Name:        existingProduct.Name,
+			Description: existingProduct.Description,
+			Price:       3000,
+			Version:     existingProduct.Version - 1,
+		})
+		require.Equal(t, apperror.Conflict(), err)
+		require.Zero(t, version)
+	})
+}
+
+func TestProductUseCase_History(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedCtx, _ := instrumentation.NewTraceSpan(
+		ctx,
+		"HistoryUseCase",
+	)
+
+	productUUID := uuid.NewString()
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+	mockProductRepo.
+		EXPECT().
+		FindProductHistoryResourceful(expectedCtx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, resource *resourceful.Resource[uuid.UUID, dtos.ProductHistoryList]) (*resourceful.Resource[uuid.UUID, dtos.ProductHistoryList], error) {
+			query, args, err := resource.OffsetQueryAndArgs(tabledefinition.ProductHistory.Field("uuid"))
+			require.NoError(t, err)
+			assert.Contains(t, query, `product_history."company_id" = $1`)
+			assert.Contains(t, query, `product_history."product_uuid" = $2`)
+			assert.Equal(t, []any{uint64(392), productUUID}, args[:2])
+
+			resource.SetResult(resourceful.Result[uuid.UUID, dtos.ProductHistoryList]{})
+			return resource, nil
+		})
+
+	productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+		ProductRepo: mockProductRepo,
+	})
+
+	resource := resourceful.NewResource[uuid.UUID, dtos.ProductHistoryList](v1.ProductHistoryDefinition)
+	resource.Parameter = &resourceful.Parameter{Limit: 10, Page: 1, Filters: []string{"changes.price notnull"}}
+
+	_, err := productUC.History(ctx, 392, productUUID, resource)
+	require.NoError(t, err)
+}
+
+func TestProductUseCase_Index_SavedView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedCtx, _ := instrumentation.NewTraceSpan(
+		ctx,
+		"IndexUseCase",
+	)
+
+	savedView := entities.ProductView{
+		CompanyId: 392,
+		UUID:      uuid.NewString(),
+		Name:      "cheap",
+		Filters:   []string{"price lt 1000"},
+		Sorts:     []string{"name asc"},
+	}
+
+	expectedResourceful := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	err := expectedResourceful.Scope(tabledefinition.Product.Field("company_id"), resourceful.EQ, uint64(392))
+	require.NoError(t, err)
+	err = expectedResourceful.AddView(savedView.View())
+	require.NoError(t, err)
+	err = expectedResourceful.SetParam(resourceful.Parameter{
+		Limit: 10,
+		Page:  1,
+		View:  "cheap",
+	})
+	require.NoError(t, err)
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+
+	mockProductRepo.
+		EXPECT().
+		FindProductViews(expectedCtx, int64(392)).
+		Return([]entities.ProductView{savedView}, nil)
+
+	mockProductRepo.
+		EXPECT().
+		FindProductResourceful(expectedCtx, expectedResourceful).
+		Return(expectedResourceful, nil)
+
+	productUC := usecase.NewProductUseCase(
+		usecase.UseCaseParameter{
+			ProductRepo:       mockProductRepo,
+			ProductDefinition: v1.ProductDefinition,
+		},
+	)
+
+	resourceProduct := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	resourceProduct.Parameter = &resourceful.Parameter{
+		Limit: 10,
+		Page:  1,
+		View:  "cheap",
+	}
+
+	_, err = productUC.Index(ctx, 392, resourceProduct)
+	require.NoError(t, err)
+}
+
+func TestProductUseCase_Index_InvalidView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+
+	mockProductRepo.
+		EXPECT().
+		FindProductViews(gomock.Any(), int64(392)).
+		Return([]entities.ProductView{}, nil)
+
+	productUC := usecase.NewProductUseCase(
+		usecase.UseCaseParameter{
+			ProductRepo:       mockProductRepo,
+			ProductDefinition: v1.ProductDefinition,
+		},
+	)
+
+	resourceProduct := resourceful.NewResource[uuid.UUID, dtos.ProductList](v1.ProductDefinition)
+	resourceProduct.Parameter = &resourceful.Parameter{
+		Limit: 10,
+		Page:  1,
+		View:  "random",
+	}
+
+	_, err := productUC.Index(context.Background(), 392, resourceProduct)
+
+	var validationErrors resourceful.ValidationErrors
+	require.ErrorAs(t, err, &validationErrors)
+	assert.Equal(t, "view", validationErrors[0].FieldName)
+}
+
+func TestProductUseCase_IndexViews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedCtx, _ := instrumentation.NewTraceSpan(
+		ctx,
+		"IndexViewsUseCase",
+	)
+
+	savedView := entities.ProductView{
+		CompanyId: 392,
+		UUID:      uuid.NewString(),
+		Name:      "cheap",
+		Filters:   []string{"price lt 1000"},
+		Sorts:     []string{},
+	}
+
+	mockProductRepo := mocks.NewMockRepository(ctrl)
+
+	mockProductRepo.
+		EXPECT().
+		FindProductViews(expectedCtx, int64(392)).
+		Return([]entities.ProductView{savedView}, nil)
+
+	productUC := usecase.NewProductUseCase(
+		usecase.UseCaseParameter{
+			ProductRepo:       mockProductRepo,
+			ProductDefinition: v1.ProductDefinition,
+		},
+	)
+
+	productViews, err := productUC.IndexViews(ctx, 392)
+	require.NoError(t, err)
+	require.Len(t, productViews, len(tabledefinition.ProductViews)+1)
+	assert.True(t, productViews[0].IsDefault)
+	assert.Equal(t, dtos.NewProductViewResponse(savedView), productViews[len(productViews)-1])
+}
+
+func TestProductUseCase_StoreView(t *testing.T) {
+	expectedCred := identityentities.Credential{
+		UserName:  "admin",
+		UserId:    1,
+		CompanyId: 392,
+	}
+
+	t.Run("error if name already exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProductRepo := mocks.NewMockRepository(ctrl)
+		mockProductRepo.
+			EXPECT().
+			FindProductViews(gomock.Any(), int64(392)).
+			Return([]entities.ProductView{{Name: "cheap"}}, nil)
+
+		productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+			ProductRepo:       mockProductRepo,
+			ProductDefinition: v1.ProductDefinition,
+		})
+
+		_, err := productUC.StoreView(context.Background(), expectedCred, entities.StoreProductView{Name: "cheap"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid filters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProductRepo := mocks.NewMockRepository(ctrl)
+		mockProductRepo.
+			EXPECT().
+			FindProductViews(gomock.Any(), int64(392)).
+			Return([]entities.ProductView{}, nil)
+
+		productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+			ProductRepo:       mockProductRepo,
+			ProductDefinition: v1.ProductDefinition,
+		})
+
+		_, err := productUC.StoreView(context.Background(), expectedCred, entities.StoreProductView{
+			Name:    "cheap",
+			Filters: []string{"company_id eq 1"},
+		})
+
+		var validationErrors resourceful.ValidationErrors
+		require.ErrorAs(t, err, &validationErrors)
+		assert.Equal(t, "view.filters.1", validationErrors[0].FieldName)
+	})
+
+	t.Run("store the view", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		expectedCtx, _ := instrumentation.NewTraceSpan(
+			ctx,
+			"StoreViewUseCase",
+		)
+		expectedUUID := uuid.NewString()
+
+		mockProductRepo := mocks.NewMockRepository(ctrl)
+		mockProductRepo.
+			EXPECT().
+			FindProductViews(expectedCtx, int64(392)).
+			Return([]entities.ProductView{}, nil)
+		mockProductRepo.
+			EXPECT().
+			StoreNewProductView(expectedCtx, gomock.Any()).
+			Return(expectedUUID, nil)
+
+		productUC := usecase.NewProductUseCase(usecase.UseCaseParameter{
+			ProductRepo:       mockProductRepo,
+			ProductDefinition: v1.ProductDefinition,
+		})
+
+		productViewUUID, err := productUC.StoreView(ctx, expectedCred, entities.StoreProductView{
+			Name:    "cheap",
+			Filters: []string{"price lt 1000"},
+			Sorts:   []string{"name asc"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedUUID, productViewUUID)
+	})
 }
 
 func createProductMatcher(product entities.Product) gomock.Matcher {
@@ -233,3 +1057,67 @@ func (e eqProductMatcher) Matches(x interface{}) bool {
 func (e eqProductMatcher) String() string {
 	return fmt.Sprintf("%v", e.product.Name)
 }
+
+func createDeleteProductMatcher(product entities.DeleteProduct) gomock.Matcher {
+	return eqDeleteProductMatcher{
+		product: product,
+	}
+}
+
+type eqDeleteProductMatcher struct {
+	product entities.DeleteProduct
+}
+
+func (e eqDeleteProductMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(entities.DeleteProduct)
+	if !ok {
+		return false
+	}
+
+	return arg.CompanyId == e.product.CompanyId &&
+		arg.UUID == e.product.UUID &&
+		!arg.DeletedOn.IsZero() &&
+		arg.DeletedBy == e.product.DeletedBy
+}
+
+func (e eqDeleteProductMatcher) String() string {
+	return fmt.Sprintf("%v", e.product.UUID)
+}
+
+// The Atomic callback is run on the same mock repository
+func expectAtomic(mockProductRepo *mocks.MockRepository) {
+	mockProductRepo.
+		EXPECT().
+		Atomic(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, opt *sql.TxOptions, cb func(tx product.Repository) error) error {
+			return cb(mockProductRepo)
+		})
+}
+
+func createProductHistoryMatcher(productHistory entities.ProductHistory) gomock.Matcher {
+	return eqProductHistoryMatcher{
+		productHistory: productHistory,
+	}
+}
+
+type eqProductHistoryMatcher struct {
+	productHistory entities.ProductHistory
+}
+
+func (e eqProductHistoryMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(entities.ProductHistory)
+	if !ok {
+		return false
+	}
+
+	// The empty product uuid matches the generated product uuid of the created product
+	return arg.CompanyId == e.productHistory.CompanyId &&
+		(e.productHistory.ProductUUID == "" || arg.ProductUUID == e.productHistory.ProductUUID) &&
+		arg.Operation == e.productHistory.Operation &&
+		reflect.DeepEqual(arg.Changes, e.productHistory.Changes) &&
+		arg.CreatedBy == e.productHistory.CreatedBy
+}
+
+func (e eqProductHistoryMatcher) String() string {
+	return fmt.Sprintf("%v %v", e.productHistory.Operation, e.productHistory.Changes)
+}
@@ -1,16 +1,21 @@
 package server
 
 import (
+	"context"
+
 	productHttpV1 "mceasy/service-demo/internal/product/delivery/http/external/v1"
+	productDtos "mceasy/service-demo/internal/product/dtos"
 	productRepository "mceasy/service-demo/internal/product/repository"
 	productUseCase "mceasy/service-demo/internal/product/usecase"
 
 	"mceasy/service-demo/internal/middleware"
+	"mceasy/service-demo/pkg/resourceful"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
-func (s *Server) MapHandlers() error {
+func (s *Server) MapHandlers(ctx context.Context) error {
 	check := s.Fiber.Group("/check")
 	check.Get("/health", func(c *fiber.Ctx) error {
 		return c.SendStatus(200)
@@ -36,13 +41,33 @@ func (s *Server) MapHandlers() error {
 	//* App repository - Internal
 	productRepo := productRepository.NewProductPGRepo(s.DB)
 
+	//* App Cache
+	var productCache *resourceful.Cache[uuid.UUID, productDtos.ProductList]
+	if s.Config.Cache.Enable {
+		productCache = resourceful.NewCache[uuid.UUID, productDtos.ProductList](
+			resourceful.NewLRUCacheStore(s.Config.Cache.Size),
+			productHttpV1.ProductDefinition,
+			s.Config.Cache.TTL,
+		)
+	}
+
 	//* App Use Case
 	productUC := productUseCase.NewProductUseCase(
 		productUseCase.UseCaseParameter{
-			ProductRepo: productRepo,
+			ProductRepo:       productRepo,
+			ProductDefinition: productHttpV1.ProductDefinition,
+			ProductCache:      productCache,
 		},
 	)
 
+	//* App Worker
+	// The zero retention would purge every soft deleted product right away
+	if s.Config.Product.PurgeInterval > 0 && s.Config.Product.SoftDeleteRetention > 0 {
+		go s.runProductPurge(ctx, productUC)
+	} else if s.Config.Product.PurgeInterval > 0 {
+		s.Logger.Warnf("Product purge disabled: SoftDeleteRetention must be positive, got %v", s.Config.Product.SoftDeleteRetention)
+	}
+
 	//* App Handler
 	// productHandler := productHttpV1.NewProductHandler(s.Config, productUC)
 	productHandler := productHttpV1.NewProductHandler(s.Config, productUC)
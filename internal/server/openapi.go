@@ -0,0 +1,16 @@
+package server
+
+import (
+	productHttpV1 "mceasy/service-demo/internal/product/delivery/http/external/v1"
+	"mceasy/service-demo/pkg/resourceful"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Serve the OpenAPI document of the resourceful Index endpoints, the definitions are initialized by the MapHandlers
+func (s *Server) OpenAPIHandler(c *fiber.Ctx) error {
+	document := resourceful.NewOpenAPIDocument(s.Config.App.Name, s.Config.App.Version)
+	document.AddIndexOperation("/external/api/web/v1/product", "List the products", productHttpV1.ProductDefinition)
+
+	return c.JSON(document)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -60,10 +61,14 @@ func (s *Server) Run() error {
 	s.Fiber.Use(recover.New(recover.Config{EnableStackTrace: true}))
 
 	// Swagger Handler
-	// s.Fiber.Get("/swagger/*", swagger.HandlerDefault)
+	s.Fiber.Get("/swagger/doc.json", s.OpenAPIHandler)
+
+	// The workers are stopped on the shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// Map App Handlers
-	err := s.MapHandlers()
+	err := s.MapHandlers(ctx)
 	if err != nil {
 		return err
 	}
@@ -74,6 +79,7 @@ func (s *Server) Run() error {
 	signal.Notify(quit, syscall.SIGINT)
 	go func() {
 		<-quit
+		cancel()
 		s.Fiber.Shutdown()
 	}()
 
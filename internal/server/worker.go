@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"mceasy/service-demo/internal/product"
+)
+
+// Purge the soft deleted products on every interval until the context is done
+func (s *Server) runProductPurge(ctx context.Context, productUC product.UseCase) {
+	ticker := time.NewTicker(s.Config.Product.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purgedCount, err := productUC.Purge(ctx, s.Config.Product.SoftDeleteRetention)
+			if err != nil {
+				s.Logger.Errorf("Product purge failed: %v", err)
+				continue
+			}
+			if purgedCount > 0 {
+				s.Logger.Infof("Product purge: %d deleted products purged", purgedCount)
+			}
+		}
+	}
+}
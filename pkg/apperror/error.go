@@ -10,6 +10,7 @@ var (
 	ErrForbiddenAccess     = errors.New("forbidden access")
 	ErrUnauthorized        = errors.New("unauthorized")
 	ErrConflict            = errors.New("conflict")
+	ErrPreconditionFailed  = errors.New("precondition failed")
 	ErrTimeout             = errors.New("timeout")
 	ErrBadRequest          = errors.New("bad request")
 	ErrGateway             = errors.New("gateway")
@@ -79,6 +80,13 @@ func Conflict() error {
 	}
 }
 
+func PreconditionFailed() error {
+	return &AppError{
+		Message: "precondition failed",
+		Err:     ErrPreconditionFailed,
+	}
+}
+
 func GatewayTimeout() error {
 	return &AppError{
 		Message: "gateway timeout",
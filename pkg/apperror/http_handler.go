@@ -99,6 +99,10 @@ func HttpHandleError(c *fiber.Ctx, err error) error {
 			return c.SendStatus(http.StatusConflict)
 		}
 
+		if errors.Is(appErr.Err, ErrPreconditionFailed) {
+			return c.SendStatus(http.StatusPreconditionFailed)
+		}
+
 		observability.SendErrorToTeams(c, err)
 		if errors.Is(appErr.Err, ErrGateway) {
 			return c.SendStatus(http.StatusGatewayTimeout)
@@ -0,0 +1,255 @@
+package resourceful
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type AggregateFunction string
+
+const (
+	COUNT AggregateFunction = "count"
+	SUM   AggregateFunction = "sum"
+	AVG   AggregateFunction = "avg"
+	MIN   AggregateFunction = "min"
+	MAX   AggregateFunction = "max"
+)
+
+var aggregateFunctions = map[AggregateFunction]string{
+	COUNT: "COUNT",
+	SUM:   "SUM",
+	AVG:   "AVG",
+	MIN:   "MIN",
+	MAX:   "MAX",
+}
+
+// Aggregation is the aggregate function over the Field, the Field is optional on COUNT (COUNT(*)).
+// The Name is the bucket value key, default to the function name followed by the field name
+type Aggregation struct {
+	Name     string
+	Function AggregateFunction
+	Field    *Field
+}
+
+// Set the group by fields & the aggregations of the Resource, the search, filters & scopes are applied
+// the same way as the QueryAndArgs. Use the AggregateQueryAndArgs & ScanAggregateRows method to get the buckets
+func (r *Resource[IDType, Model]) Aggregate(groupBy []*Field, aggregates ...Aggregation) error {
+	if !r.isProcessed || r.isAPIResource {
+		return createError("SetParam method must be called")
+	}
+	if len(aggregates) == 0 {
+		return createError("aggregations can't be empty")
+	}
+
+	for _, field := range groupBy {
+		if field == nil || field.table == nil || !r.tableDefinition.hasTable(field.table) {
+			return createError("group by field must be a field of the resource definition")
+		}
+	}
+
+	var normalizedAggregates []Aggregation
+	usedNames := make(map[string]bool)
+	for _, aggregate := range aggregates {
+		if _, ok := aggregateFunctions[aggregate.Function]; !ok {
+			return createError(fmt.Sprintf(`invalid "%s" aggregate function`, aggregate.Function))
+		}
+
+		if aggregate.Field == nil {
+			if aggregate.Function != COUNT {
+				return createError(fmt.Sprintf(`"%s" aggregate field can't be empty`, aggregate.Function))
+			}
+		} else {
+			if aggregate.Field.table == nil || !r.tableDefinition.hasTable(aggregate.Field.table) {
+				return createError("aggregate field must be a field of the resource definition")
+			}
+			if (aggregate.Function == SUM || aggregate.Function == AVG) && aggregate.Field.Type != NUMERIC && aggregate.Field.Type != INTEGER {
+				return createError(fmt.Sprintf(`"%s" aggregate field must be a %s or %s type`, aggregate.Function, NUMERIC, INTEGER))
+			}
+		}
+
+		if aggregate.Name == "" {
+			aggregate.Name = string(aggregate.Function)
+			if aggregate.Field != nil {
+				aggregate.Name += "_" + aggregate.Field.Name
+			}
+		}
+		if usedNames[aggregate.Name] {
+			return createError(fmt.Sprintf(`duplicate "%s" aggregate name`, aggregate.Name))
+		}
+		usedNames[aggregate.Name] = true
+
+		normalizedAggregates = append(normalizedAggregates, aggregate)
+	}
+
+	// Reset the aggregate state
+	r.unuseTableFor(AGGREGATE)
+	for _, field := range groupBy {
+		r.useFieldFor(field, AGGREGATE)
+	}
+	for _, aggregate := range normalizedAggregates {
+		if aggregate.Field != nil {
+			r.useFieldFor(aggregate.Field, AGGREGATE)
+		}
+	}
+
+	r.groupByFields = append([]*Field{}, groupBy...)
+	r.aggregations = normalizedAggregates
+
+	return nil
+}
+
+// Get the Resource aggregate query & args, the result rows are the group by fields followed by the aggregations
+func (r *Resource[IDType, Model]) AggregateQueryAndArgs() (string, []any, error) {
+	if !r.isProcessed {
+		return "", nil, createError("SetParam method must be called")
+	}
+	if len(r.aggregations) == 0 {
+		return "", nil, createError("aggregations can't be empty. Use the Aggregate method instead")
+	}
+
+	var queryStatement string
+
+	// Select Statement
+	var (
+		selectStatements  []string
+		groupByStatements []string
+	)
+	for _, field := range r.groupByFields {
+		selectStatements = append(selectStatements, field.statement)
+		groupByStatements = append(groupByStatements, field.statement)
+	}
+	for _, aggregate := range r.aggregations {
+		fieldStatement := "*"
+		if aggregate.Field != nil {
+			fieldStatement = aggregate.Field.statement
+		}
+
+		selectStatements = append(selectStatements, fmt.Sprintf("%s(%s)", aggregateFunctions[aggregate.Function], fieldStatement))
+	}
+	queryStatement += fmt.Sprintf("SELECT %s FROM %s", strings.Join(selectStatements, ", "), r.tableDefinition.statement)
+
+	// Join Statements
+	joinStatements := r.getJoinStatements([]string{AGGREGATE, SEARCH, FILTER, OPTIONAL_FILTER, MANDATORY})
+	if len(joinStatements) > 0 {
+		queryStatement += "\n" + strings.Join(joinStatements, "\n")
+	}
+
+	// Where Statement
+	whereStatements := append([]string{}, r.whereStatements...)
+	whereStatements = append(whereStatements, r.defaultWhereStatements...)
+	if len(whereStatements) > 0 {
+		queryStatement += "\nWHERE " + strings.Join(whereStatements, "\nAND ")
+	}
+
+	// Group By & Sort Statement
+	if len(groupByStatements) > 0 {
+		queryStatement += "\nGROUP BY " + strings.Join(groupByStatements, ", ")
+		queryStatement += "\nORDER BY " + strings.Join(groupByStatements, ", ")
+	}
+
+	return queryStatement, append([]any{}, r.queryArgs...), nil
+}
+
+// Read the AggregateQueryAndArgs result rows into the buckets. The values are typed by the field type,
+// COUNT is an int64, SUM & AVG are a float64 and NULL is a nil
+func (r *Resource[IDType, Model]) ScanAggregateRows(rows Rows) ([]Bucket, error) {
+	buckets := make([]Bucket, 0)
+
+	for rows.Next() {
+		var dest []any
+		for _, field := range r.groupByFields {
+			dest = append(dest, scanDestination(field.Type))
+		}
+		for _, aggregate := range r.aggregations {
+			switch {
+			case aggregate.Function == COUNT:
+				dest = append(dest, new(int64))
+			case aggregate.Function == SUM || aggregate.Function == AVG:
+				dest = append(dest, new(sql.NullFloat64))
+			default:
+				dest = append(dest, scanDestination(aggregate.Field.Type))
+			}
+		}
+
+		err := rows.Scan(dest...)
+		if err != nil {
+			return nil, err
+		}
+
+		bucket := Bucket{Groups: make(map[string]any), Values: make(map[string]any)}
+		for key, field := range r.groupByFields {
+			bucket.Groups[fieldKey(field)] = scannedValue(dest[key])
+		}
+		for key, aggregate := range r.aggregations {
+			bucket.Values[aggregate.Name] = scannedValue(dest[len(r.groupByFields)+key])
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+func fieldKey(field *Field) string {
+	if field.Alias != "" {
+		return field.Alias
+	}
+
+	return field.Name
+}
+
+func scanDestination(fieldType string) any {
+	switch fieldType {
+	case NUMERIC:
+		return new(sql.NullFloat64)
+	case INTEGER:
+		return new(sql.NullInt64)
+	case BOOLEAN:
+		return new(sql.NullBool)
+	case STRING, UUID, ENUM:
+		return new(sql.NullString)
+	case DATE:
+		return new(sql.NullTime)
+	}
+
+	return new(any)
+}
+
+func scannedValue(dest any) any {
+	switch dest := dest.(type) {
+	case *int64:
+		return *dest
+	case *sql.NullFloat64:
+		if dest.Valid {
+			return dest.Float64
+		}
+	case *sql.NullInt64:
+		if dest.Valid {
+			return dest.Int64
+		}
+	case *sql.NullBool:
+		if dest.Valid {
+			return dest.Bool
+		}
+	case *sql.NullString:
+		if dest.Valid {
+			return dest.String
+		}
+	case *sql.NullTime:
+		if dest.Valid {
+			return dest.Time
+		}
+	case *any:
+		if byteValue, ok := (*dest).([]byte); ok {
+			return string(byteValue)
+		}
+		return *dest
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package resourceful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_Aggregate(t *testing.T) {
+	productDefinition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+
+	t.Run("error if SetParam is not called", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+
+		err := resource.Aggregate(nil, Aggregation{Function: COUNT})
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid aggregation", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.SetParam(Parameter{})
+		require.NoError(t, err)
+
+		require.Error(t, resource.Aggregate(nil))
+		require.Error(t, resource.Aggregate([]*Field{{Name: "company_id"}}, Aggregation{Function: COUNT}))
+		require.Error(t, resource.Aggregate(nil, Aggregation{Function: "median", Field: productTable.Field("count")}))
+		require.Error(t, resource.Aggregate(nil, Aggregation{Function: SUM}))
+		require.Error(t, resource.Aggregate(nil, Aggregation{Function: AVG, Field: productTable.Field("name")}))
+		require.Error(t, resource.Aggregate(nil, Aggregation{Function: COUNT}, Aggregation{Function: COUNT}))
+
+		_, _, err = resource.AggregateQueryAndArgs()
+		require.Error(t, err)
+	})
+
+	t.Run("query with group by, filters & scopes", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.Scope(productTable.Field("company_id"), EQ, 392)
+		require.NoError(t, err)
+		err = resource.SetParam(Parameter{Filters: []string{"count gt 2"}, Sorts: []string{"name asc"}})
+		require.NoError(t, err)
+
+		err = resource.Aggregate(
+			[]*Field{productTypeTable.Field("name")},
+			Aggregation{Function: COUNT},
+			Aggregation{Name: "total_count", Function: SUM, Field: productTable.Field("count")},
+		)
+		require.NoError(t, err)
+
+		query, args, err := resource.AggregateQueryAndArgs()
+		require.NoError(t, err)
+		require.Equal(t, `SELECT pt."name", COUNT(*), SUM(product."count") FROM product
+JOIN product_type pt ON product."product_type_id" = pt."id"
+WHERE product."count" > $1
+AND product."company_id" = $2
+AND product."is_deleted" is false
+GROUP BY pt."name"
+ORDER BY pt."name"`, query)
+		require.Equal(t, []any{"2", 392}, args)
+	})
+
+	t.Run("sum & avg of the integer field", func(t *testing.T) {
+		typedTable := newTypedTable()
+		typedDefinition, err := NewDefinition(typedTable)
+		require.NoError(t, err)
+
+		resource := NewResource[string, string](typedDefinition)
+		err = resource.SetParam(Parameter{})
+		require.NoError(t, err)
+
+		err = resource.Aggregate(
+			nil,
+			Aggregation{Function: SUM, Field: typedTable.Field("quantity")},
+			Aggregation{Function: AVG, Field: typedTable.Field("quantity")},
+		)
+		require.NoError(t, err)
+		require.Error(t, resource.Aggregate(nil, Aggregation{Function: SUM, Field: typedTable.Field("created_on")}))
+
+		query, _, err := resource.AggregateQueryAndArgs()
+		require.NoError(t, err)
+		require.Equal(t, `SELECT SUM(item."quantity"), AVG(item."quantity") FROM item`, query)
+
+		buckets, err := resource.ScanAggregateRows(&fakeRows{rows: [][]any{{int64(12), "1.5"}}})
+		require.NoError(t, err)
+		require.Equal(t, []Bucket{{
+			Groups: map[string]any{},
+			Values: map[string]any{"sum_quantity": float64(12), "avg_quantity": 1.5},
+		}}, buckets)
+	})
+
+	t.Run("scan rows into buckets", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.SetParam(Parameter{})
+		require.NoError(t, err)
+
+		err = resource.Aggregate(
+			[]*Field{productTable.Field("name")},
+			Aggregation{Function: COUNT},
+			Aggregation{Function: AVG, Field: productTable.Field("count")},
+			Aggregation{Function: MAX, Field: productTable.Field("count")},
+		)
+		require.NoError(t, err)
+
+		buckets, err := resource.ScanAggregateRows(&fakeRows{rows: [][]any{
+			{"apple", int64(2), "1.5", int64(2)},
+			{nil, int64(1), nil, nil},
+		}})
+		require.NoError(t, err)
+		require.Equal(t, []Bucket{
+			{
+				Groups: map[string]any{"name": "apple"},
+				Values: map[string]any{"count": int64(2), "avg_count": 1.5, "max_count": float64(2)},
+			},
+			{
+				Groups: map[string]any{"name": nil},
+				Values: map[string]any{"count": int64(1), "avg_count": nil, "max_count": nil},
+			},
+		}, buckets)
+	})
+}
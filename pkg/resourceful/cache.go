@@ -0,0 +1,329 @@
+package resourceful
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CacheStore is the cache backend of the Cache, e.g. the in-memory LRU or the Redis-compatible store.
+// The Incr value must be readable by the Get as the decimal string (the Redis INCR behaviour) & must not be evicted,
+// the evicted namespace generation would serve the results cached before the Invalidate again
+type CacheStore interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// Cache keeps the Resource result keyed by the normalized Parameter, scopes, soft delete mode & definition version. The key is
+// namespaced by the tenant (e.g. the company id), the Invalidate method drops every cached result of the namespace
+type Cache[IDType, Model any] struct {
+	store   CacheStore
+	prefix  string
+	version string
+	ttl     time.Duration
+}
+
+type cacheEntry[IDType, Model any] struct {
+	Ids             []IDType                `json:"ids"`
+	PaginatedResult []Model                 `json:"paginated_result"`
+	Facets          map[string][]FacetValue `json:"facets,omitempty"`
+	TotalCount      int                     `json:"total_count"`
+	IsCounted       bool                    `json:"is_counted"`
+	NextCursor      string                  `json:"next_cursor"`
+}
+
+// Create a new Cache of the definition, the Model must be JSON encodable
+func NewCache[IDType, Model any](store CacheStore, definition *Definition, ttl time.Duration) *Cache[IDType, Model] {
+	return &Cache[IDType, Model]{
+		store:   store,
+		prefix:  "resourceful:" + definition.tableDefinition.Name,
+		version: definition.Version(),
+		ttl:     ttl,
+	}
+}
+
+// Load the cached result to the Resource, return false on the cache miss. The SetParam method must be called first.
+// The key is kept on the Resource for the Set, so the result queried during the Invalidate is stored under the old generation
+func (c *Cache[IDType, Model]) Get(ctx context.Context, namespace string, resource *Resource[IDType, Model]) (bool, error) {
+	key, err := c.key(ctx, namespace, resource)
+	if err != nil {
+		return false, err
+	}
+	resource.cacheStoreKey = key
+
+	value, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		return false, errors.Wrap(err, "Cache.Get.StoreGet")
+	}
+	if !ok {
+		return false, nil
+	}
+
+	var entry cacheEntry[IDType, Model]
+	err = json.Unmarshal(value, &entry)
+	if err != nil {
+		return false, errors.Wrap(err, "Cache.Get.Unmarshal")
+	}
+
+	resource.SetResult(Result[IDType, Model]{Ids: entry.Ids, PaginatedResult: entry.PaginatedResult})
+	resource.facets = entry.Facets
+	resource.totalCount = entry.TotalCount
+	resource.isCounted = entry.IsCounted
+	resource.nextCursor = entry.NextCursor
+
+	return true, nil
+}
+
+// Store the Resource result, the result must be set first. The key of the previous Get is reused
+func (c *Cache[IDType, Model]) Set(ctx context.Context, namespace string, resource *Resource[IDType, Model]) error {
+	if resource.result == nil {
+		return createError("result must be set. Use the SetResult method instead")
+	}
+
+	key := resource.cacheStoreKey
+	if key == "" {
+		var err error
+		key, err = c.key(ctx, namespace, resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	value, err := json.Marshal(cacheEntry[IDType, Model]{
+		Ids:             resource.result.Ids,
+		PaginatedResult: resource.result.PaginatedResult,
+		Facets:          resource.facets,
+		TotalCount:      resource.totalCount,
+		IsCounted:       resource.isCounted,
+		NextCursor:      resource.nextCursor,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Cache.Set.Marshal")
+	}
+
+	err = c.store.Set(ctx, key, value, c.ttl)
+	if err != nil {
+		return errors.Wrap(err, "Cache.Set.StoreSet")
+	}
+
+	return nil
+}
+
+// Invalidate every cached result of the namespace by moving the namespace generation, the old keys are left to expire
+func (c *Cache[IDType, Model]) Invalidate(ctx context.Context, namespace string) error {
+	_, err := c.store.Incr(ctx, c.generationKey(namespace))
+	if err != nil {
+		return errors.Wrap(err, "Cache.Invalidate.StoreIncr")
+	}
+
+	return nil
+}
+
+func (c *Cache[IDType, Model]) generationKey(namespace string) string {
+	return fmt.Sprintf("%s:%s:generation", c.prefix, namespace)
+}
+
+func (c *Cache[IDType, Model]) key(ctx context.Context, namespace string, resource *Resource[IDType, Model]) (string, error) {
+	if !resource.isProcessed || resource.isAPIResource {
+		return "", createError("SetParam method must be called")
+	}
+
+	generation, ok, err := c.store.Get(ctx, c.generationKey(namespace))
+	if err != nil {
+		return "", errors.Wrap(err, "Cache.key.StoreGet")
+	}
+	if !ok {
+		generation = []byte("0")
+	}
+
+	return fmt.Sprintf("%s:%s:%s:%s:%s", c.prefix, namespace, generation, c.version, resource.cacheKey()), nil
+}
+
+// The hash of the normalized Parameter, scopes, soft delete mode & applied view. The order of the filters, fields, facets
+// & includes doesn't change the result
+func (r *Resource[IDType, Model]) cacheKey() string {
+	param := *r.Parameter
+	if param.Pagination == "" {
+		param.Pagination = PAGE_PAGINATION
+	}
+	param.Search = strings.Join(strings.Fields(param.Search), " ")
+	param.Filters = normalizedParams(param.Filters, true)
+	param.LocalFilters = normalizedParams(param.LocalFilters, true)
+	param.Sorts = normalizedParams(param.Sorts, false)
+	param.Fields = normalizedParams(param.Fields, true)
+	param.Facets = normalizedParams(param.Facets, true)
+	param.Includes = normalizedParams(param.Includes, true)
+
+	var scopes []string
+	for _, scope := range r.scopes {
+		scopes = append(scopes, fmt.Sprintf("%s %s %v", scope.field.statement, scope.operator, scope.values))
+	}
+
+	// The added view (e.g. the user saved view) can be changed under the same name
+	var view View
+	if param.View != "" {
+		view = r.viewsMap[param.View]
+	}
+
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%+v|%v|%s|%+v", param, scopes, r.softDeleteMode, view)
+
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
+
+func normalizedParams(params []string, isSorted bool) []string {
+	normalized := make([]string, 0, len(params))
+	for _, param := range params {
+		normalized = append(normalized, strings.Join(strings.Fields(param), " "))
+	}
+	if isSorted {
+		sort.Strings(normalized)
+	}
+
+	return normalized
+}
+
+// The version of the definition, the cached result of the changed definition is not reused
+func (d *Definition) Version() string {
+	hash := fnv.New64a()
+
+	fmt.Fprintf(hash, "%s|%v|%v|", d.searchStrategy, d.defaultWhereStatements, d.policy)
+	for _, key := range d.fieldKeys(func(field *Field) bool { return true }) {
+		field := d.fieldsMap[key]
+		fmt.Fprintf(hash, "%s=%s %s %v %t %t %t %t %t %t %v;", key, field.statement, field.Type, field.Values,
+			field.Filterable, field.PatternFilterable, field.LocalFilterable, field.Sortable, field.Selectable, field.Facetable, field.FacetRanges)
+	}
+	for _, field := range d.searchFields {
+		fmt.Fprintf(hash, "%s %s %s;", field.statement, field.SearchWeight, field.SearchLanguage)
+	}
+	for _, field := range d.defaultSortFields {
+		fmt.Fprintf(hash, "%s %s;", field.statement, field.Sort)
+	}
+	for _, name := range d.collectionNames() {
+		collection := d.collectionsMap[name]
+		fmt.Fprintf(hash, "%s=%s %s;", name, collection.Table.Name, collection.ForeignKeyField.statement)
+		for _, field := range collection.Table.Fields {
+			fmt.Fprintf(hash, "%s %t;", field.statement, field.Selectable)
+		}
+	}
+
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
+
+// LRUCacheStore is the in-memory CacheStore, the least recently used entry is evicted when the size is exceeded.
+// The Incr counters are kept apart & never evicted
+type LRUCacheStore struct {
+	mutex    sync.Mutex
+	size     int
+	entries  *list.List
+	keys     map[string]*list.Element
+	counters map[string]int64
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiredOn time.Time
+}
+
+// Create a new LRUCacheStore with the max number of entries
+func NewLRUCacheStore(size int) *LRUCacheStore {
+	return &LRUCacheStore{
+		size:     size,
+		entries:  list.New(),
+		keys:     make(map[string]*list.Element),
+		counters: make(map[string]int64),
+	}
+}
+
+func (l *LRUCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if counter, ok := l.counters[key]; ok {
+		return []byte(strconv.FormatInt(counter, 10)), true, nil
+	}
+
+	element, ok := l.keys[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !entry.expiredOn.IsZero() && time.Now().After(entry.expiredOn) {
+		l.remove(element)
+		return nil, false, nil
+	}
+
+	l.entries.MoveToFront(element)
+
+	return entry.value, true, nil
+}
+
+// Set the value, the zero ttl never expires
+func (l *LRUCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.counters, key)
+	l.set(key, value, ttl)
+
+	return nil
+}
+
+func (l *LRUCacheStore) Incr(ctx context.Context, key string) (int64, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// The Set value is moved to the counters
+	value := l.counters[key]
+	if element, ok := l.keys[key]; ok {
+		entry := element.Value.(*lruEntry)
+		if entry.expiredOn.IsZero() || time.Now().Before(entry.expiredOn) {
+			parsedValue, err := strconv.ParseInt(string(entry.value), 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("value of %s key is not an integer", key)
+			}
+			value = parsedValue
+		}
+		l.remove(element)
+	}
+
+	value++
+	l.counters[key] = value
+
+	return value, nil
+}
+
+func (l *LRUCacheStore) set(key string, value []byte, ttl time.Duration) {
+	var expiredOn time.Time
+	if ttl > 0 {
+		expiredOn = time.Now().Add(ttl)
+	}
+
+	if element, ok := l.keys[key]; ok {
+		element.Value = &lruEntry{key: key, value: value, expiredOn: expiredOn}
+		l.entries.MoveToFront(element)
+		return
+	}
+
+	l.keys[key] = l.entries.PushFront(&lruEntry{key: key, value: value, expiredOn: expiredOn})
+	for l.size > 0 && l.entries.Len() > l.size {
+		l.remove(l.entries.Back())
+	}
+}
+
+func (l *LRUCacheStore) remove(element *list.Element) {
+	l.entries.Remove(element)
+	delete(l.keys, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,195 @@
+package resourceful
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCacheStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("evict the least recently used entry", func(t *testing.T) {
+		store := NewLRUCacheStore(2)
+		require.NoError(t, store.Set(ctx, "a", []byte("1"), 0))
+		require.NoError(t, store.Set(ctx, "b", []byte("2"), 0))
+
+		_, ok, err := store.Get(ctx, "a")
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		require.NoError(t, store.Set(ctx, "c", []byte("3"), 0))
+
+		_, ok, _ = store.Get(ctx, "b")
+		require.False(t, ok)
+		value, ok, _ := store.Get(ctx, "a")
+		require.True(t, ok)
+		require.Equal(t, []byte("1"), value)
+	})
+
+	t.Run("expire by the ttl", func(t *testing.T) {
+		store := NewLRUCacheStore(2)
+		require.NoError(t, store.Set(ctx, "a", []byte("1"), time.Nanosecond))
+		time.Sleep(time.Millisecond)
+
+		_, ok, err := store.Get(ctx, "a")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("incr the integer value", func(t *testing.T) {
+		store := NewLRUCacheStore(2)
+		value, err := store.Incr(ctx, "a")
+		require.NoError(t, err)
+		require.Equal(t, int64(1), value)
+
+		value, err = store.Incr(ctx, "a")
+		require.NoError(t, err)
+		require.Equal(t, int64(2), value)
+
+		storedValue, _, _ := store.Get(ctx, "a")
+		require.Equal(t, []byte("2"), storedValue)
+
+		require.NoError(t, store.Set(ctx, "b", []byte("x"), 0))
+		_, err = store.Incr(ctx, "b")
+		require.Error(t, err)
+	})
+
+	t.Run("counter is not evicted", func(t *testing.T) {
+		store := NewLRUCacheStore(1)
+		_, err := store.Incr(ctx, "generation")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Set(ctx, "a", []byte("1"), 0))
+		require.NoError(t, store.Set(ctx, "b", []byte("2"), 0))
+
+		value, ok, err := store.Get(ctx, "generation")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []byte("1"), value)
+	})
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	definition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+
+	newScopedResource := func(companyId int, param Parameter) *Resource[string, string] {
+		resource := NewResource[string, string](definition)
+		require.NoError(t, resource.Scope(productTable.Field("company_id"), EQ, companyId))
+		require.NoError(t, resource.SetParam(param))
+		return resource
+	}
+
+	t.Run("error if SetParam is not called", func(t *testing.T) {
+		cache := NewCache[string, string](NewLRUCacheStore(10), definition, time.Minute)
+
+		_, err := cache.Get(ctx, "392", NewResource[string, string](definition))
+		require.Error(t, err)
+	})
+
+	t.Run("error if result is not set", func(t *testing.T) {
+		cache := NewCache[string, string](NewLRUCacheStore(10), definition, time.Minute)
+
+		err := cache.Set(ctx, "392", newScopedResource(392, Parameter{Limit: 10, Page: 1}))
+		require.Error(t, err)
+	})
+
+	t.Run("load the cached result by the normalized parameter", func(t *testing.T) {
+		cache := NewCache[string, string](NewLRUCacheStore(10), definition, time.Minute)
+
+		resource := newScopedResource(392, Parameter{Limit: 10, Page: 1, Filters: []string{"name eq a", "count gt 1"}})
+		resource.SetResult(Result[string, string]{Ids: []string{"a"}, PaginatedResult: []string{"apple"}})
+		require.NoError(t, cache.Set(ctx, "392", resource))
+
+		cachedResource := newScopedResource(392, Parameter{Limit: 10, Page: 1, Filters: []string{"count  gt 1", "name eq a"}})
+		ok, err := cache.Get(ctx, "392", cachedResource)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, resource.Response(), cachedResource.Response())
+
+		// The other param
+		ok, err = cache.Get(ctx, "392", newScopedResource(392, Parameter{Limit: 10, Page: 2, Filters: []string{"name eq a", "count gt 1"}}))
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("the scope is part of the key", func(t *testing.T) {
+		cache := NewCache[string, string](NewLRUCacheStore(10), definition, time.Minute)
+
+		resource := newScopedResource(392, Parameter{Limit: 10, Page: 1})
+		resource.SetResult(Result[string, string]{Ids: []string{"a"}, PaginatedResult: []string{"apple"}})
+		require.NoError(t, cache.Set(ctx, "392", resource))
+
+		ok, err := cache.Get(ctx, "392", newScopedResource(393, Parameter{Limit: 10, Page: 1}))
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("the soft delete mode is part of the key", func(t *testing.T) {
+		cache := NewCache[string, string](NewLRUCacheStore(10), definition, time.Minute)
+
+		resource := newScopedResource(392, Parameter{Limit: 10, Page: 1})
+		resource.SetResult(Result[string, string]{Ids: []string{"a"}, PaginatedResult: []string{"apple"}})
+		require.NoError(t, cache.Set(ctx, "392", resource))
+
+		deletedResource := newScopedResource(392, Parameter{Limit: 10, Page: 1})
+		require.NoError(t, deletedResource.SetSoftDeleteMode(ONLY_DELETED))
+		ok, err := cache.Get(ctx, "392", deletedResource)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("result queried during the invalidate is stored under the old generation", func(t *testing.T) {
+		cache := NewCache[string, string](NewLRUCacheStore(10), definition, time.Minute)
+
+		resource := newScopedResource(392, Parameter{Limit: 10, Page: 1})
+		ok, err := cache.Get(ctx, "392", resource)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		require.NoError(t, cache.Invalidate(ctx, "392"))
+
+		resource.SetResult(Result[string, string]{Ids: []string{"a"}, PaginatedResult: []string{"stale apple"}})
+		require.NoError(t, cache.Set(ctx, "392", resource))
+
+		ok, err = cache.Get(ctx, "392", newScopedResource(392, Parameter{Limit: 10, Page: 1}))
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("invalidate the namespace", func(t *testing.T) {
+		cache := NewCache[string, string](NewLRUCacheStore(10), definition, time.Minute)
+
+		for _, namespace := range []string{"392", "393"} {
+			resource := newScopedResource(392, Parameter{Limit: 10, Page: 1})
+			resource.SetResult(Result[string, string]{Ids: []string{"a"}, PaginatedResult: []string{"apple"}})
+			require.NoError(t, cache.Set(ctx, namespace, resource))
+		}
+
+		require.NoError(t, cache.Invalidate(ctx, "392"))
+
+		ok, err := cache.Get(ctx, "392", newScopedResource(392, Parameter{Limit: 10, Page: 1}))
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = cache.Get(ctx, "393", newScopedResource(392, Parameter{Limit: 10, Page: 1}))
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+}
+
+func TestDefinition_Version(t *testing.T) {
+	definition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+	sameDefinition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+	require.Equal(t, definition.Version(), sameDefinition.Version())
+
+	fulltextDefinition, err := NewDefinition(&productTable, DefinitionOption{SearchStrategy: FULLTEXT_SEARCH})
+	require.NoError(t, err)
+	require.NotEqual(t, definition.Version(), fulltextDefinition.Version())
+}
@@ -0,0 +1,269 @@
+package resourceful
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+type sortOrder struct {
+	field    *Field
+	operator string
+}
+
+type cursor struct {
+	Signature string `json:"s"`
+	Values    []any  `json:"v"`
+}
+
+// Rows is the minimal rows reader used to scan the keyset query result, satisfied by *sql.Rows & *sqlx.Rows
+type Rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}
+
+// Check if the Resource use the keyset (cursor) pagination
+func (r *Resource[IDType, Model]) IsCursorPagination() bool {
+	return r.Parameter != nil && r.Parameter.Pagination == CURSOR_PAGINATION
+}
+
+// Get the Resource keyset query & args, the result rows are the idField followed by the active sort fields.
+// Use the ScanCursorRows method to read the result rows
+func (r *Resource[IDType, Model]) CursorQueryAndArgs(idField *Field) (string, []any, error) {
+	if !r.isProcessed {
+		return "", nil, createError("SetParam method must be called")
+	}
+	if !r.IsCursorPagination() {
+		return "", nil, createError("cursor pagination must be used")
+	}
+	if idField == nil {
+		return "", nil, createError("id field can't be empty")
+	}
+
+	var queryStatement string
+
+	// Select Statement
+	selectStatements := []string{idField.statement}
+	for _, sortOrder := range r.sortOrders {
+		selectStatements = append(selectStatements, sortOrder.field.statement)
+	}
+	queryStatement += fmt.Sprintf("SELECT %s FROM %s", strings.Join(selectStatements, ", "), r.tableDefinition.statement)
+
+	// Join Statements
+	joinStatements := r.getJoinStatements([]string{SEARCH, FILTER, OPTIONAL_FILTER, SORT, MANDATORY})
+	if len(joinStatements) > 0 {
+		queryStatement += "\n" + strings.Join(joinStatements, "\n")
+	}
+
+	// Where Statement
+	queryArgs := append([]any{}, r.queryArgs...)
+	whereStatements := append([]string{}, r.whereStatements...)
+	whereStatements = append(whereStatements, r.defaultWhereStatements...)
+	if r.cursorValues != nil {
+		var seekStatement string
+		seekStatement, queryArgs = r.seekStatement(idField, queryArgs)
+		whereStatements = append(whereStatements, seekStatement)
+	}
+	if len(whereStatements) > 0 {
+		queryStatement += "\nWHERE " + strings.Join(whereStatements, "\nAND ")
+	}
+
+	// Sort Statement
+	sortStatements := append([]string{}, r.sortStatements...)
+	sortStatements = append(sortStatements, fmt.Sprintf("%s %s", idField.statement, sortOperators["asc"]))
+	queryStatement += "\nORDER BY " + strings.Join(sortStatements, ", ")
+
+	// Limit Statement, fetch one more row to know if the next page exists
+	queryArgs = append(queryArgs, r.Parameter.Limit+1)
+	queryStatement += fmt.Sprintf("\nLIMIT $%d", len(queryArgs))
+
+	return queryStatement, queryArgs, nil
+}
+
+// Read the CursorQueryAndArgs result rows, return the ids of the current page and set the next cursor
+func (r *Resource[IDType, Model]) ScanCursorRows(rows Rows) ([]IDType, error) {
+	var (
+		ids        []IDType
+		lastValues []any
+	)
+
+	r.nextCursor = ""
+	for rows.Next() {
+		if len(ids) == r.Parameter.Limit {
+			cursorValue, err := encodeCursor(r.cursorSignature(), lastValues)
+			if err != nil {
+				return nil, err
+			}
+
+			r.nextCursor = cursorValue
+			break
+		}
+
+		var id IDType
+		values := make([]any, len(r.sortOrders))
+		dest := []any{&id}
+		for key := range values {
+			dest = append(dest, &values[key])
+		}
+
+		err := rows.Scan(dest...)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+		lastValues = append(values, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *Resource[IDType, Model]) processPagination(param Parameter) error {
+	var validationErrors ValidationErrors
+
+	switch param.Pagination {
+	case "", PAGE_PAGINATION:
+		if param.Cursor != "" {
+			validationErrors.appendFieldError("cursor", "only available on cursor pagination")
+		}
+	case CURSOR_PAGINATION:
+		if param.Limit < 1 {
+			validationErrors.appendFieldError("limit", "must be greater than 0 on cursor pagination")
+		}
+		if param.Cursor == "" {
+			break
+		}
+
+		values, ok := decodeCursor(param.Cursor, r.cursorSignature())
+		if !ok || len(values) != len(r.sortOrders)+1 {
+			validationErrors.appendFieldError("cursor", "invalid cursor")
+			break
+		}
+
+		r.cursorValues = values
+	default:
+		validationErrors.appendFieldError("pagination", "invalid pagination")
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	return nil
+}
+
+// The cursor signature is bound to the active sorts, so a cursor can't be reused with different sorts
+func (r *Resource[IDType, Model]) cursorSignature() string {
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.Join(r.sortStatements, ",")))
+
+	return fmt.Sprintf("%x", hash.Sum32())
+}
+
+// Build the seek statement to get the rows after the cursor, following the postgres default null ordering
+// (NULLS LAST on ASC, NULLS FIRST on DESC)
+func (r *Resource[IDType, Model]) seekStatement(idField *Field, queryArgs []any) (string, []any) {
+	orders := append([]sortOrder{}, r.sortOrders...)
+	orders = append(orders, sortOrder{field: idField, operator: "asc"})
+
+	placeholders := make([]string, len(orders))
+	for key, value := range r.cursorValues {
+		if value != nil {
+			queryArgs = append(queryArgs, value)
+			placeholders[key] = fmt.Sprintf("$%d", len(queryArgs))
+		}
+	}
+
+	var seekStatements []string
+	for key, order := range orders {
+		var statements []string
+		for prevKey := 0; prevKey < key; prevKey++ {
+			if r.cursorValues[prevKey] == nil {
+				statements = append(statements, fmt.Sprintf("%s IS NULL", orders[prevKey].field.statement))
+			} else {
+				statements = append(statements, fmt.Sprintf("%s = %s", orders[prevKey].field.statement, placeholders[prevKey]))
+			}
+		}
+
+		isIdField := key == len(orders)-1
+		switch {
+		case order.operator == "desc" && r.cursorValues[key] == nil:
+			statements = append(statements, fmt.Sprintf("%s IS NOT NULL", order.field.statement))
+		case order.operator == "desc":
+			statements = append(statements, fmt.Sprintf("%s < %s", order.field.statement, placeholders[key]))
+		case r.cursorValues[key] == nil:
+			// Nothing comes after NULL on ASC order
+			continue
+		case isIdField:
+			statements = append(statements, fmt.Sprintf("%s > %s", order.field.statement, placeholders[key]))
+		default:
+			statements = append(statements, fmt.Sprintf("(%s > %s OR %s IS NULL)", order.field.statement, placeholders[key], order.field.statement))
+		}
+
+		seekStatements = append(seekStatements, strings.Join(statements, " AND "))
+	}
+
+	if len(seekStatements) == 0 {
+		return "false", queryArgs
+	}
+
+	return fmt.Sprintf("((%s))", strings.Join(seekStatements, ") OR (")), queryArgs
+}
+
+func encodeCursor(signature string, values []any) (string, error) {
+	normalizedValues := make([]any, 0, len(values))
+	for _, value := range values {
+		if byteValue, ok := value.([]byte); ok {
+			value = string(byteValue)
+		}
+
+		normalizedValues = append(normalizedValues, value)
+	}
+
+	jsonCursor, err := json.Marshal(cursor{Signature: signature, Values: normalizedValues})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(jsonCursor), nil
+}
+
+func decodeCursor(cursorParam, signature string) ([]any, bool) {
+	jsonCursor, err := base64.RawURLEncoding.DecodeString(cursorParam)
+	if err != nil {
+		return nil, false
+	}
+
+	var decodedCursor cursor
+	decoder := json.NewDecoder(bytes.NewReader(jsonCursor))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decodedCursor); err != nil {
+		return nil, false
+	}
+
+	if decodedCursor.Signature != signature {
+		return nil, false
+	}
+
+	values := make([]any, 0, len(decodedCursor.Values))
+	for _, value := range decodedCursor.Values {
+		switch value := value.(type) {
+		case json.Number:
+			values = append(values, value.String())
+		case nil, string, bool:
+			values = append(values, value)
+		default:
+			return nil, false
+		}
+	}
+
+	return values, true
+}
@@ -2,35 +2,58 @@ package resourceful
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 )
 
+var expressionColumnRegexp = regexp.MustCompile(`\{[A-Za-z_][A-Za-z0-9_]*\}`)
+
 type Table struct {
 	Name      string
 	Alias     string
 	Fields    []*Field
 	Relations []*Relation
+	// The to-many relations loaded by the Parameter includes, only on the resource table
+	Collections []*Collection
 
 	statement string
+	// The aliased relation table is a copy of the source table with its own field key namespace
+	source    *Table
+	keyPrefix string
 }
 
 type Relation struct {
+	// The relation Alias gives the relation table its own SQL alias & field keys (e.g. "creator.name"),
+	// required to join the same table more than once or to join the table itself.
+	// The Relation is replaced by the aliased copy at the NewDefinition
+	Alias             string
 	IsMandatory       bool
 	ForeignKeyField   *Field
 	ReferenceKeyField *Field
 	Table             *Table
+
+	isAliased bool
 }
 
 type Field struct {
-	Name            string
-	Alias           string
-	Type            string
-	Searchable      bool
-	Filterable      bool
-	LocalFilterable bool
-	Sortable        bool
-	Sort            string
-	SoftDeleteField bool
+	Name              string
+	Alias             string
+	Expression        string // Computed field SQL expression, "{column}" is the column of the same table. e.g. coalesce({updated_on}, {created_on})
+	Type              string
+	Values            []string // The allowed values of the ENUM field
+	Searchable        bool
+	SearchWeight      string
+	SearchLanguage    string
+	Filterable        bool
+	PatternFilterable bool
+	LocalFilterable   bool
+	Sortable          bool
+	Selectable        bool
+	Facetable         bool
+	FacetRanges       []float64 // The ascending bounds of the NUMERIC or INTEGER range facet, e.g. the price bands
+	Sort              string
+	SoftDeleteField   bool // The boolean deleted flag or the DATE deleted timestamp (NULL when not deleted)
 
 	statement string
 	table     *Table
@@ -40,7 +63,7 @@ func (table *Table) init() (map[string]*Field, []*Field, []string, []Field, map[
 	fieldsMap := make(map[string]*Field)
 	defaultUsedTablesMap := make(map[*Table]map[string]bool)
 
-	searchFields, defaultWhereStatements, defaultSortFields, err := table.dfsInit(fieldsMap, defaultUsedTablesMap)
+	searchFields, defaultWhereStatements, defaultSortFields, err := table.dfsInit(fieldsMap, defaultUsedTablesMap, make(map[*Table]bool))
 	if err != nil {
 		return nil, nil, nil, nil, nil, err
 	}
@@ -48,7 +71,7 @@ func (table *Table) init() (map[string]*Field, []*Field, []string, []Field, map[
 	return fieldsMap, searchFields, defaultWhereStatements, defaultSortFields, defaultUsedTablesMap, nil
 }
 
-func (table *Table) dfsInit(fieldsMap map[string]*Field, defaultUsedTablesMap map[*Table]map[string]bool) ([]*Field, []string, []Field, error) {
+func (table *Table) dfsInit(fieldsMap map[string]*Field, defaultUsedTablesMap map[*Table]map[string]bool, ancestors map[*Table]bool) ([]*Field, []string, []Field, error) {
 	selfFieldsMap := make(map[string]bool)
 	var (
 		searchFields           []*Field
@@ -73,6 +96,14 @@ func (table *Table) dfsInit(fieldsMap map[string]*Field, defaultUsedTablesMap ma
 
 		// Set the Field properties
 		field.statement = fmt.Sprintf(`%s."%s"`, tableSelector, field.Name)
+		if field.Expression != "" {
+			expressionStatement, err := table.expressionStatement(field, tableSelector)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			field.statement = expressionStatement
+		}
 		field.table = table
 
 		// Get the Field Key
@@ -80,12 +111,41 @@ func (table *Table) dfsInit(fieldsMap map[string]*Field, defaultUsedTablesMap ma
 		if field.Alias != "" {
 			fieldKey = field.Alias
 		}
-		if _, ok := fieldsMap[fieldKey]; ok {
+		if table.keyPrefix != "" {
+			fieldKey = fmt.Sprintf("%s.%s", table.keyPrefix, fieldKey)
+		} else if _, ok := fieldsMap[fieldKey]; ok {
 			fieldKey = fmt.Sprintf("%s.%s", table.Name, field.Name)
 		}
 
+		// Pattern filter only for string field
+		if field.PatternFilterable && field.Type != STRING {
+			return nil, nil, nil, createError(fmt.Sprintf(`pattern filterable "%s" field must be a %s type`, field.Name, STRING))
+		}
+
+		// The ENUM values are required & only for the ENUM field
+		if (field.Type == ENUM) != (len(field.Values) > 0) {
+			return nil, nil, nil, createError(fmt.Sprintf(`enum values at "%s" field must be set only on the %s type`, field.Name, ENUM))
+		}
+
+		// JSONB field is only filterable by the path or selectable
+		if field.Type == JSONB && (field.Searchable || field.Sortable || field.Facetable || field.Sort != "") {
+			return nil, nil, nil, createError(fmt.Sprintf(`%s "%s" field can only be filterable or selectable`, JSONB, field.Name))
+		}
+
+		// The range facet bounds must be ascending & only on the Facetable NUMERIC or INTEGER field
+		if len(field.FacetRanges) > 0 {
+			if !field.Facetable || (field.Type != NUMERIC && field.Type != INTEGER) {
+				return nil, nil, nil, createError(fmt.Sprintf(`facet ranges at "%s" field must be set only on the facetable %s or %s type`, field.Name, NUMERIC, INTEGER))
+			}
+			for key := 1; key < len(field.FacetRanges); key++ {
+				if field.FacetRanges[key] <= field.FacetRanges[key-1] {
+					return nil, nil, nil, createError(fmt.Sprintf(`facet ranges at "%s" field must be ascending`, field.Name))
+				}
+			}
+		}
+
 		// Append the fieldsMap
-		if field.Filterable || field.LocalFilterable || field.Sortable {
+		if field.Filterable || field.PatternFilterable || field.LocalFilterable || field.Sortable || field.Selectable || field.Facetable {
 			fieldsMap[fieldKey] = field
 		}
 
@@ -96,7 +156,10 @@ func (table *Table) dfsInit(fieldsMap map[string]*Field, defaultUsedTablesMap ma
 
 		// defaultWhereStatements (soft delete only for now)
 		if field.SoftDeleteField {
-			defaultWhereStatements = append(defaultWhereStatements, fmt.Sprintf("%s is false", field.statement))
+			if field.Type != "" && field.Type != BOOLEAN && field.Type != DATE {
+				return nil, nil, nil, createError(fmt.Sprintf(`soft delete "%s" field must be a %s or %s type`, field.Name, BOOLEAN, DATE))
+			}
+			defaultWhereStatements = append(defaultWhereStatements, softDeleteStatement(field, false))
 			useFieldAs(defaultUsedTablesMap, field, FILTER)
 		}
 
@@ -113,16 +176,30 @@ func (table *Table) dfsInit(fieldsMap map[string]*Field, defaultUsedTablesMap ma
 	}
 
 	// DFS Relations
+	ancestors[table.origin()] = true
+	defer delete(ancestors, table.origin())
+
+	// The aliased relations are copies, the Relation of the caller is kept as is
+	relations := make([]*Relation, 0, len(table.Relations))
 	for _, relation := range table.Relations {
-		if relation.Table == table {
-			return nil, nil, nil, createError(fmt.Sprintf(`circular relations on "%s" table`, table.Name))
+		if relation.Alias != "" && !relation.isAliased {
+			aliasedRelation, err := relation.alias(relation.Alias, relation.Alias, ancestors)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			relation = aliasedRelation
+		}
+		relations = append(relations, relation)
+
+		if !relation.isAliased && ancestors[relation.Table.origin()] {
+			return nil, nil, nil, createError(fmt.Sprintf(`circular relations on "%s" table, use the relation alias instead`, table.Name))
 		}
 
 		if relation.IsMandatory {
 			useTableAs(defaultUsedTablesMap, relation.Table, MANDATORY)
 		}
 
-		relationSearchFields, relationDefaultWhereStatement, relationDefaultSortFields, err := relation.Table.dfsInit(fieldsMap, defaultUsedTablesMap)
+		relationSearchFields, relationDefaultWhereStatement, relationDefaultSortFields, err := relation.Table.dfsInit(fieldsMap, defaultUsedTablesMap, ancestors)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -132,10 +209,126 @@ func (table *Table) dfsInit(fieldsMap map[string]*Field, defaultUsedTablesMap ma
 		defaultWhereStatements = append(defaultWhereStatements, relationDefaultWhereStatement...)
 		defaultSortFields = append(defaultSortFields, relationDefaultSortFields...)
 	}
+	table.Relations = relations
 
 	return searchFields, defaultWhereStatements, defaultSortFields, nil
 }
 
+// Replace the "{column}" of the Field expression by the column statement, only the column (not computed) field of the same table is allowed
+func (table *Table) expressionStatement(field *Field, tableSelector string) (string, error) {
+	var err error
+
+	statement := expressionColumnRegexp.ReplaceAllStringFunc(field.Expression, func(column string) string {
+		columnField := table.Field(column[1 : len(column)-1])
+		if columnField == nil || columnField.Expression != "" {
+			err = createError(fmt.Sprintf(`invalid "%s" column at "%s" computed field`, column, field.Name))
+			return column
+		}
+
+		return fmt.Sprintf(`%s."%s"`, tableSelector, columnField.Name)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if strings.ContainsAny(statement, "{};") {
+		return "", createError(fmt.Sprintf(`invalid expression at "%s" computed field`, field.Name))
+	}
+
+	return fmt.Sprintf("(%s)", statement), nil
+}
+
+// Copy the relation with the aliased copy of the relation table. The copy of the relations are aliased too,
+// the relation back to the ancestor tables is dropped from the copy so the self join is a single level
+func (relation *Relation) alias(sqlAlias, keyPrefix string, ancestors map[*Table]bool) (*Relation, error) {
+	source := relation.Table.origin()
+	aliasedTable := &Table{
+		Name:      source.Name,
+		Alias:     sqlAlias,
+		source:    source,
+		keyPrefix: keyPrefix,
+	}
+	for _, field := range source.Fields {
+		aliasedField := *field
+		aliasedTable.Fields = append(aliasedTable.Fields, &aliasedField)
+	}
+
+	childAncestors := map[*Table]bool{source: true}
+	for ancestor := range ancestors {
+		childAncestors[ancestor] = true
+	}
+
+	for _, sourceRelation := range source.Relations {
+		childSource := sourceRelation.Table.origin()
+		if childAncestors[childSource] {
+			continue
+		}
+
+		childName := sourceRelation.Alias
+		if childName == "" {
+			childName = childSource.Name
+		}
+
+		var foreignKeyField *Field
+		if sourceRelation.ForeignKeyField != nil {
+			foreignKeyField = aliasedTable.Field(sourceRelation.ForeignKeyField.Name)
+		}
+		if foreignKeyField == nil {
+			return nil, createError(fmt.Sprintf(`foreign key field of "%s" relation must be a field of the "%s" table`, childName, source.Name))
+		}
+
+		aliasedRelation, err := (&Relation{
+			Alias:             sourceRelation.Alias,
+			IsMandatory:       sourceRelation.IsMandatory,
+			ForeignKeyField:   foreignKeyField,
+			ReferenceKeyField: sourceRelation.ReferenceKeyField,
+			Table:             childSource,
+		}).alias(sqlAlias+"_"+childName, keyPrefix+"."+childName, childAncestors)
+		if err != nil {
+			return nil, err
+		}
+
+		aliasedTable.Relations = append(aliasedTable.Relations, aliasedRelation)
+	}
+
+	var referenceKeyField *Field
+	if relation.ReferenceKeyField != nil {
+		referenceKeyField = aliasedTable.Field(relation.ReferenceKeyField.Name)
+	}
+	if referenceKeyField == nil {
+		return nil, createError(fmt.Sprintf(`reference key field of "%s" relation must be a field of the "%s" table`, keyPrefix, source.Name))
+	}
+
+	return &Relation{
+		Alias:             relation.Alias,
+		IsMandatory:       relation.IsMandatory,
+		ForeignKeyField:   relation.ForeignKeyField,
+		ReferenceKeyField: referenceKeyField,
+		Table:             aliasedTable,
+		isAliased:         true,
+	}, nil
+}
+
+// Get the source table of the aliased table
+func (table *Table) origin() *Table {
+	if table.source != nil {
+		return table.source
+	}
+
+	return table
+}
+
+// Get the relation by the relation alias or the relation table name
+func (table *Table) Relation(name string) *Relation {
+	for _, relation := range table.Relations {
+		if relation.Alias == name || (relation.Alias == "" && relation.Table.Name == name) {
+			return relation
+		}
+	}
+
+	return nil
+}
+
 func (table *Table) getRequiredRelations(usedTables map[*Table]bool) []*Relation {
 	var requiredRelations []*Relation
 	var requiredRelationsMap = make(map[*Relation]int)
@@ -155,7 +348,8 @@ func (table *Table) getRequiredRelations(usedTables map[*Table]bool) []*Relation
 
 func (table *Table) dfsGetRequiredRelations(relationPaths []*Relation, usedTables map[*Table]bool, result map[*Relation]int) {
 	for _, relation := range table.Relations {
-		relationPaths = append(relationPaths, relation)
+		// Copy the paths so the sibling relations are not part of the path
+		relationPaths := append(relationPaths[:len(relationPaths):len(relationPaths)], relation)
 
 		// Search if exists, append the result Map
 		for usedTable := range usedTables {
@@ -170,6 +364,21 @@ func (table *Table) dfsGetRequiredRelations(relationPaths []*Relation, usedTable
 	}
 }
 
+// Check if the table is the table itself or one of its relation tables
+func (table *Table) hasTable(target *Table) bool {
+	if table == target {
+		return true
+	}
+
+	for _, relation := range table.Relations {
+		if relation.Table.hasTable(target) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (table *Table) Field(name string) *Field {
 	for _, field := range table.Fields {
 		if field.Name == name {
@@ -17,14 +17,14 @@ func init() {
 	productTypeTable.Alias = "pt"
 	productTypeTable.Fields = []*Field{
 		{Name: "id"},
-		{Name: "name", Searchable: true, Filterable: true},
+		{Name: "name", Searchable: true, Filterable: true, Facetable: true},
 	}
 
 	productTable.Name = "product"
 	productTable.Fields = []*Field{
 		{Name: "id"},
-		{Name: "name", Type: STRING, Searchable: true, Filterable: true, Sortable: true},
-		{Name: "count", Type: NUMERIC, Filterable: true},
+		{Name: "name", Type: STRING, Searchable: true, Filterable: true, PatternFilterable: true, Sortable: true, Selectable: true},
+		{Name: "count", Type: NUMERIC, Filterable: true, Selectable: true, Facetable: true},
 		{Name: "product_type_id"},
 		{Name: "company_id", LocalFilterable: true},
 		{Name: "created_on", Sort: "desc", Sortable: true},
@@ -107,6 +107,18 @@ func TestDefinition_init(t *testing.T) {
 		_, _, _, _, _, err := duplicateFieldTable.init()
 		require.Error(t, err)
 	})
+
+	t.Run("error_if_pattern_filterable_field_is_not_string", func(t *testing.T) {
+		patternFieldTable := Table{
+			Name: "pattern_field_table",
+			Fields: []*Field{
+				{Name: "count", Type: NUMERIC, PatternFilterable: true},
+			},
+		}
+
+		_, _, _, _, _, err := patternFieldTable.init()
+		require.Error(t, err)
+	})
 }
 
 func TestDefinition_getRelationTables(t *testing.T) {
@@ -134,3 +146,193 @@ func TestDefinition_getRelationTables(t *testing.T) {
 		require.NotEmpty(t, results)
 	})
 }
+
+func TestTable_ComputedField(t *testing.T) {
+	t.Run("error if invalid expression column", func(t *testing.T) {
+		table := &Table{Name: "order_line", Fields: []*Field{
+			{Name: "price"},
+			{Name: "total", Expression: "{price} * {qty}", Sortable: true},
+		}}
+
+		_, err := NewDefinition(table)
+		require.Error(t, err)
+	})
+
+	t.Run("error if expression column is computed", func(t *testing.T) {
+		table := &Table{Name: "order_line", Fields: []*Field{
+			{Name: "price"},
+			{Name: "qty"},
+			{Name: "total", Expression: "{price} * {qty}"},
+			{Name: "double_total", Expression: "{total} * 2", Sortable: true},
+		}}
+
+		_, err := NewDefinition(table)
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid expression", func(t *testing.T) {
+		table := &Table{Name: "order_line", Fields: []*Field{
+			{Name: "price"},
+			{Name: "total", Expression: "{price}; DROP TABLE order_line", Sortable: true},
+		}}
+
+		_, err := NewDefinition(table)
+		require.Error(t, err)
+	})
+
+	t.Run("computed field is sortable, filterable & selectable", func(t *testing.T) {
+		table := &Table{Name: "order_line", Alias: "ol", Fields: []*Field{
+			{Name: "id"},
+			{Name: "name", Type: STRING, Searchable: true},
+			{Name: "price"},
+			{Name: "qty"},
+			{Name: "total", Type: NUMERIC, Expression: "{price} * {qty}", Sortable: true, Filterable: true, Selectable: true},
+		}}
+
+		definition, err := NewDefinition(table)
+		require.NoError(t, err)
+
+		resource := NewResource[string, string](definition)
+		err = resource.SetParam(Parameter{Limit: 10, Page: 1, Filters: []string{"total gte 100"}, Sorts: []string{"total desc"}, Fields: []string{"total"}})
+		require.NoError(t, err)
+
+		resource.Select(append([]*Field{table.Field("id")}, resource.SelectedFields()...))
+		query, args, err := resource.QueryAndArgs()
+		require.NoError(t, err)
+		require.Equal(t, `SELECT ol."id", (ol."price" * ol."qty") AS "total" FROM order_line ol
+WHERE (ol."price" * ol."qty") >= $1
+ORDER BY (ol."price" * ol."qty") DESC`, query)
+		require.Equal(t, []any{"100"}, args)
+	})
+}
+
+func TestTable_RelationAlias(t *testing.T) {
+	t.Run("error if circular relations without alias", func(t *testing.T) {
+		categoryTable := &Table{Name: "category", Fields: []*Field{{Name: "id"}, {Name: "parent_id"}}}
+		categoryTable.Relations = []*Relation{
+			{Table: categoryTable, ForeignKeyField: categoryTable.Field("parent_id"), ReferenceKeyField: categoryTable.Field("id")},
+		}
+
+		_, err := NewDefinition(categoryTable)
+		require.Error(t, err)
+	})
+
+	t.Run("self join with alias", func(t *testing.T) {
+		categoryTable := &Table{Name: "category", Fields: []*Field{
+			{Name: "id"},
+			{Name: "parent_id"},
+			{Name: "name", Type: STRING, Searchable: true, Filterable: true, Sortable: true},
+		}}
+		categoryTable.Relations = []*Relation{
+			{Alias: "parent", Table: categoryTable, ForeignKeyField: categoryTable.Field("parent_id"), ReferenceKeyField: categoryTable.Field("id")},
+		}
+
+		definition, err := NewDefinition(categoryTable)
+		require.NoError(t, err)
+
+		resource := NewResource[string, string](definition)
+		err = resource.SetParam(Parameter{Limit: 10, Page: 1, Filters: []string{"parent.name eq food"}, Sorts: []string{"name asc"}})
+		require.NoError(t, err)
+
+		query, _, err := resource.OffsetQueryAndArgs(categoryTable.Field("id"))
+		require.NoError(t, err)
+		require.Equal(t, `SELECT category."id", COUNT(*) OVER() FROM category
+JOIN category parent ON category."parent_id" = parent."id"
+WHERE parent."name" = $1
+ORDER BY category."name" ASC, category."id" ASC
+LIMIT $2 OFFSET $3`, query)
+
+		// The aliased copy has no relation back to the category
+		require.Empty(t, categoryTable.Relation("parent").Table.Relations)
+	})
+
+	t.Run("keep the relation of the caller", func(t *testing.T) {
+		categoryTable := &Table{Name: "category", Fields: []*Field{{Name: "id"}, {Name: "parent_id"}}}
+		parentRelation := &Relation{Alias: "parent", Table: categoryTable, ForeignKeyField: categoryTable.Field("parent_id"), ReferenceKeyField: categoryTable.Field("id")}
+		categoryTable.Relations = []*Relation{parentRelation}
+
+		_, err := NewDefinition(categoryTable)
+		require.NoError(t, err)
+
+		require.Same(t, categoryTable, parentRelation.Table)
+		require.Same(t, categoryTable.Field("id"), parentRelation.ReferenceKeyField)
+		require.NotSame(t, parentRelation, categoryTable.Relation("parent"))
+	})
+
+	t.Run("error if the key field of the aliased relation is missing", func(t *testing.T) {
+		companyTable := &Table{Name: "company", Fields: []*Field{{Name: "id"}}}
+		userTable := &Table{Name: "user", Fields: []*Field{{Name: "id"}}}
+		userTable.Relations = []*Relation{
+			{Table: companyTable, ForeignKeyField: &Field{Name: "company_id"}, ReferenceKeyField: companyTable.Field("id")},
+		}
+		itemTable := &Table{Name: "item", Fields: []*Field{{Name: "id"}, {Name: "created_by_id"}}}
+		itemTable.Relations = []*Relation{
+			{Alias: "creator", Table: userTable, ForeignKeyField: itemTable.Field("created_by_id"), ReferenceKeyField: userTable.Field("id")},
+		}
+
+		_, err := NewDefinition(itemTable)
+		require.Error(t, err)
+
+		userTable.Relations = nil
+		itemTable.Relations[0].ReferenceKeyField = nil
+
+		_, err = NewDefinition(itemTable)
+		require.Error(t, err)
+	})
+
+	t.Run("multiple relation paths to the same table", func(t *testing.T) {
+		companyTable := &Table{Name: "company", Alias: "c", Fields: []*Field{
+			{Name: "id"},
+			{Name: "name", Type: STRING, Filterable: true},
+		}}
+		userTable := &Table{Name: "user", Alias: "u", Fields: []*Field{
+			{Name: "id"},
+			{Name: "company_id"},
+			{Name: "name", Type: STRING, Filterable: true, Sortable: true},
+		}}
+		userTable.Relations = []*Relation{
+			{Table: companyTable, ForeignKeyField: userTable.Field("company_id"), ReferenceKeyField: companyTable.Field("id")},
+		}
+		itemTable := &Table{Name: "item", Fields: []*Field{
+			{Name: "id"},
+			{Name: "name", Type: STRING, Searchable: true},
+			{Name: "created_by_id"},
+			{Name: "updated_by_id"},
+		}}
+		itemTable.Relations = []*Relation{
+			{Alias: "creator", Table: userTable, ForeignKeyField: itemTable.Field("created_by_id"), ReferenceKeyField: userTable.Field("id")},
+			{Alias: "updater", Table: userTable, ForeignKeyField: itemTable.Field("updated_by_id"), ReferenceKeyField: userTable.Field("id")},
+		}
+
+		definition, err := NewDefinition(itemTable)
+		require.NoError(t, err)
+
+		// The definition can be created again with the aliased tables
+		_, err = NewDefinition(itemTable)
+		require.NoError(t, err)
+
+		resource := NewResource[string, string](definition)
+		err = resource.SetParam(Parameter{
+			Limit:   10,
+			Page:    1,
+			Filters: []string{"creator.name eq alice", "updater.company.name eq acme"},
+			Sorts:   []string{"updater.name desc"},
+		})
+		require.NoError(t, err)
+
+		query, _, err := resource.OffsetQueryAndArgs(itemTable.Field("id"))
+		require.NoError(t, err)
+		require.Contains(t, query, `JOIN user creator ON item."created_by_id" = creator."id"`)
+		require.Contains(t, query, `LEFT JOIN user updater ON item."updated_by_id" = updater."id"`)
+		require.Contains(t, query, `JOIN company updater_company ON updater."company_id" = updater_company."id"`)
+		require.NotContains(t, query, "creator_company")
+		require.Contains(t, query, `WHERE creator."name" = $1
+AND updater_company."name" = $2
+ORDER BY updater."name" DESC`)
+
+		// The user table itself is not used by the aliased relations
+		require.NotSame(t, userTable, itemTable.Relation("creator").Table)
+		require.NotSame(t, itemTable.Relation("creator").Table, itemTable.Relation("updater").Table)
+		require.Equal(t, `creator."name"`, itemTable.Relation("creator").Table.Field("name").statement)
+	})
+}
@@ -0,0 +1,252 @@
+package resourceful
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mceasy/service-demo/pkg/database"
+
+	"github.com/pkg/errors"
+)
+
+// Number of the rows fetched per round trip by the export cursor
+const exportFetchSize = 1000
+
+// Executor runs the two-phase resourceful flow of the entity repository: the page query of the ids (offset or cursor),
+// the facets, the populate query of the selected fields and the included collections. The populate rows are
+// scanned into the Model by the db tags
+type Executor[IDType, Model any] struct {
+	db      database.Queryer
+	idField *Field
+	fields  []*Field
+}
+
+// Create a new Executor, the fields are selected when the Parameter fields is empty. The idField is always selected
+func NewExecutor[IDType, Model any](db database.Queryer, idField *Field, fields []*Field) *Executor[IDType, Model] {
+	return &Executor[IDType, Model]{
+		db:      db,
+		idField: idField,
+		fields:  fields,
+	}
+}
+
+// Run the Resource queries & set the result to the Resource, the SetParam method must be called first.
+// The policy statement timeout is only applied when the Executor db is a transaction
+func (e *Executor[IDType, Model]) Find(ctx context.Context, resource *Resource[IDType, Model]) error {
+	if e.idField == nil {
+		return createError("id field can't be empty")
+	}
+
+	err := resource.SetStatementTimeout(ctx, e.db)
+	if err != nil {
+		return errors.Wrap(err, "Executor.Find.SetStatementTimeout")
+	}
+
+	ids, err := e.findIds(ctx, resource)
+	if err != nil {
+		return err
+	}
+
+	// Facets are computed even on the empty page, the filter of the facet field itself is excluded
+	for _, facetField := range resource.FacetFields() {
+		err := e.findFacet(ctx, resource, facetField)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(ids) == 0 {
+		resource.SetResult(Result[IDType, Model]{})
+		return nil
+	}
+
+	result, err := e.populate(ctx, resource, ids)
+	if err != nil {
+		return err
+	}
+
+	resource.SetResult(Result[IDType, Model]{Ids: ids, PaginatedResult: result})
+
+	// Included collections are loaded by one query per collection & nested into each result
+	for _, collection := range resource.IncludedCollections() {
+		err := e.findInclude(ctx, resource, collection, ids)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stream the Resource query (search, filters, scopes & sorts without the pagination) to the writer by the server-side cursor.
+// The cursor only lives inside the transaction, so the Executor db must be a transaction
+func (e *Executor[IDType, Model]) Export(ctx context.Context, resource *Resource[IDType, Model], w io.Writer, format string) error {
+	if e.idField == nil {
+		return createError("id field can't be empty")
+	}
+
+	selectedFields := resource.SelectedFields()
+	if len(selectedFields) == 0 {
+		selectedFields = e.fields
+	}
+	resource.Select(append([]*Field{e.idField}, selectedFields...))
+
+	query, args, err := resource.QueryAndArgs()
+	if err != nil {
+		return errors.Wrap(err, "Executor.Export.QueryAndArgs")
+	}
+
+	exporter, err := resource.NewExporter(w, format)
+	if err != nil {
+		return errors.Wrap(err, "Executor.Export.NewExporter")
+	}
+
+	err = resource.SetStatementTimeout(ctx, e.db)
+	if err != nil {
+		return errors.Wrap(err, "Executor.Export.SetStatementTimeout")
+	}
+
+	_, err = e.db.ExecContext(ctx, "DECLARE resourceful_export NO SCROLL CURSOR FOR "+query, args...)
+	if err != nil {
+		return errors.Wrap(err, "Executor.Export.DeclareCursor")
+	}
+
+	for {
+		rows, err := e.db.QueryxContext(ctx, fmt.Sprintf("FETCH %d FROM resourceful_export", exportFetchSize))
+		if err != nil {
+			return errors.Wrap(err, "Executor.Export.FetchCursor")
+		}
+
+		count, err := exporter.WriteRows(rows)
+		rows.Close()
+		if err != nil {
+			return errors.Wrap(err, "Executor.Export.WriteRows")
+		}
+
+		if count < exportFetchSize {
+			break
+		}
+	}
+
+	_, err = e.db.ExecContext(ctx, "CLOSE resourceful_export")
+	if err != nil {
+		return errors.Wrap(err, "Executor.Export.CloseCursor")
+	}
+
+	err = exporter.Close()
+	if err != nil {
+		return errors.Wrap(err, "Executor.Export.CloseExporter")
+	}
+
+	return nil
+}
+
+func (e *Executor[IDType, Model]) findIds(ctx context.Context, resource *Resource[IDType, Model]) ([]IDType, error) {
+	var (
+		query string
+		args  []any
+		err   error
+	)
+	if resource.IsCursorPagination() {
+		query, args, err = resource.CursorQueryAndArgs(e.idField)
+	} else {
+		query, args, err = resource.OffsetQueryAndArgs(e.idField)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Executor.findIds.PaginatedQueryAndArgs")
+	}
+
+	rows, err := e.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "Executor.findIds.QueryxContext")
+	}
+	defer rows.Close()
+
+	var ids []IDType
+	if resource.IsCursorPagination() {
+		ids, err = resource.ScanCursorRows(rows)
+	} else {
+		ids, err = resource.ScanOffsetRows(rows)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Executor.findIds.ScanPaginatedRows")
+	}
+
+	return ids, nil
+}
+
+func (e *Executor[IDType, Model]) findFacet(ctx context.Context, resource *Resource[IDType, Model], facetField *Field) error {
+	query, args, err := resource.FacetQueryAndArgs(facetField)
+	if err != nil {
+		return errors.Wrap(err, "Executor.findFacet.FacetQueryAndArgs")
+	}
+
+	rows, err := e.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "Executor.findFacet.QueryxContext")
+	}
+	defer rows.Close()
+
+	err = resource.ScanFacetRows(facetField, rows)
+	if err != nil {
+		return errors.Wrap(err, "Executor.findFacet.ScanFacetRows")
+	}
+
+	return nil
+}
+
+func (e *Executor[IDType, Model]) populate(ctx context.Context, resource *Resource[IDType, Model], ids []IDType) ([]Model, error) {
+	selectedFields := resource.SelectedFields()
+	if len(selectedFields) == 0 {
+		selectedFields = e.fields
+	}
+	resource.Select(append([]*Field{e.idField}, selectedFields...))
+
+	query, args, err := resource.PopulateQueryArgs(e.idField, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "Executor.populate.PopulateQueryArgs")
+	}
+
+	rows, err := e.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "Executor.populate.QueryxContext")
+	}
+	defer rows.Close()
+
+	var result []Model
+	for rows.Next() {
+		var model Model
+
+		err := rows.StructScan(&model)
+		if err != nil {
+			return nil, errors.Wrap(err, "Executor.populate.StructScan")
+		}
+
+		result = append(result, model)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "Executor.populate.Rows")
+	}
+
+	return result, nil
+}
+
+func (e *Executor[IDType, Model]) findInclude(ctx context.Context, resource *Resource[IDType, Model], collection *Collection, ids []IDType) error {
+	query, args, err := resource.IncludeQueryAndArgs(collection, ids)
+	if err != nil {
+		return errors.Wrap(err, "Executor.findInclude.IncludeQueryAndArgs")
+	}
+
+	rows, err := e.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "Executor.findInclude.QueryxContext")
+	}
+	defer rows.Close()
+
+	err = resource.ScanIncludeRows(collection, rows)
+	if err != nil {
+		return errors.Wrap(err, "Executor.findInclude.ScanIncludeRows")
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package resourceful
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+// The result of the executed query matched by the query prefix
+type fakeQuery struct {
+	prefix  string
+	columns []string
+	rows    [][]driver.Value
+}
+
+type fakeExecution struct {
+	query string
+	args  []any
+}
+
+// fakeConnector is the database/sql connector of the Executor tests, the query is answered by the first matched fakeQuery
+type fakeConnector struct {
+	queries    []fakeQuery
+	executions []fakeExecution
+}
+
+func newFakeDB(queries ...fakeQuery) (*sqlx.DB, *fakeConnector) {
+	connector := &fakeConnector{queries: queries}
+
+	return sqlx.NewDb(sql.OpenDB(connector), "postgres"), connector
+}
+
+func (c *fakeConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeConn{connector: c}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return fakeDriver{connector: c}
+}
+
+type fakeDriver struct {
+	connector *fakeConnector
+}
+
+func (d fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{connector: d.connector}, nil
+}
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transaction is not supported")
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, namedArgs []driver.NamedValue) (driver.Rows, error) {
+	var args []any
+	for _, namedArg := range namedArgs {
+		args = append(args, namedArg.Value)
+	}
+	c.connector.executions = append(c.connector.executions, fakeExecution{query: query, args: args})
+
+	for _, fakeQuery := range c.connector.queries {
+		if strings.HasPrefix(query, fakeQuery.prefix) {
+			return &fakeDriverRows{columns: fakeQuery.columns, rows: fakeQuery.rows}, nil
+		}
+	}
+
+	return nil, errors.New("unexpected query: " + query)
+}
+
+type fakeDriverRows struct {
+	columns []string
+	rows    [][]driver.Value
+	index   int
+}
+
+func (f *fakeDriverRows) Columns() []string {
+	return f.columns
+}
+
+func (f *fakeDriverRows) Close() error {
+	return nil
+}
+
+func (f *fakeDriverRows) Next(dest []driver.Value) error {
+	if f.index >= len(f.rows) {
+		return io.EOF
+	}
+
+	copy(dest, f.rows[f.index])
+	f.index++
+
+	return nil
+}
+
+func TestExecutor_Find(t *testing.T) {
+	variantProductTable, _ := newVariantTables()
+	definition, err := NewDefinition(variantProductTable)
+	require.NoError(t, err)
+
+	idField := variantProductTable.Field("id")
+	fields := []*Field{variantProductTable.Field("name")}
+
+	populateQuery := fakeQuery{
+		prefix:  `SELECT product."id", product."name" FROM product`,
+		columns: []string{"id", "name"},
+		rows:    [][]driver.Value{{"b", "banana"}, {"a", "apple"}},
+	}
+
+	t.Run("error if id field is empty", func(t *testing.T) {
+		db, _ := newFakeDB()
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1})
+		require.NoError(t, err)
+
+		err = NewExecutor[string, productWithVariants](db, nil, fields).Find(context.Background(), resource)
+		require.Error(t, err)
+	})
+
+	t.Run("offset page populated by the ids order & nested includes", func(t *testing.T) {
+		db, connector := newFakeDB(
+			fakeQuery{
+				prefix:  `SELECT product."id", COUNT(*) OVER() FROM product`,
+				columns: []string{"id", "count"},
+				rows:    [][]driver.Value{{"b", int64(3)}, {"a", int64(3)}},
+			},
+			populateQuery,
+			fakeQuery{
+				prefix:  `SELECT pv."product_id", pv."name", pv."price" FROM product_variant pv`,
+				columns: []string{"product_id", "name", "price"},
+				rows:    [][]driver.Value{{"a", "small", int64(10)}, {"b", "large", int64(20)}, {"b", "jumbo", int64(30)}},
+			},
+		)
+
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 2, Page: 1, Includes: []string{"variants"}})
+		require.NoError(t, err)
+
+		err = NewExecutor[string, productWithVariants](db, idField, fields).Find(context.Background(), resource)
+		require.NoError(t, err)
+
+		require.Len(t, connector.executions, 3)
+		require.Equal(t, []any{int64(2), int64(0)}, connector.executions[0].args)
+		require.Contains(t, connector.executions[1].query, `WHERE product."id" IN ($1, $2)`)
+		require.Contains(t, connector.executions[1].query, `ORDER BY product."id" ASC`)
+		require.Equal(t, []any{"b", "a"}, connector.executions[1].args)
+		require.Equal(t, []any{"b", "a"}, connector.executions[2].args)
+
+		response := resource.Response()
+		require.Equal(t, &Metadata{Count: 2, Page: 1, TotalCount: 3, TotalPage: 2}, response.Metadata)
+		require.Equal(t, []string{"b", "a"}, response.Data.Ids)
+		require.Equal(t, []productWithVariants{
+			{ID: "b", Name: "banana", Variants: []variantList{{Name: "large", Price: 20}, {Name: "jumbo", Price: 30}}},
+			{ID: "a", Name: "apple", Variants: []variantList{{Name: "small", Price: 10}}},
+		}, response.Data.PaginatedResult)
+	})
+
+	t.Run("cursor page with the next cursor", func(t *testing.T) {
+		db, connector := newFakeDB(
+			fakeQuery{
+				prefix:  `SELECT product."id" FROM product`,
+				columns: []string{"id"},
+				rows:    [][]driver.Value{{"b"}, {"a"}},
+			},
+			populateQuery,
+		)
+
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Pagination: CURSOR_PAGINATION, Limit: 1})
+		require.NoError(t, err)
+
+		err = NewExecutor[string, productWithVariants](db, idField, fields).Find(context.Background(), resource)
+		require.NoError(t, err)
+
+		require.Len(t, connector.executions, 2)
+		require.Equal(t, []any{int64(2)}, connector.executions[0].args)
+		require.Equal(t, []any{"b"}, connector.executions[1].args)
+
+		metadata, ok := resource.Response().Metadata.(*CursorMetadata)
+		require.True(t, ok)
+		require.True(t, metadata.HasNext)
+		require.NotEmpty(t, metadata.NextCursor)
+		require.Equal(t, []string{"b"}, resource.Response().Data.Ids)
+	})
+
+	t.Run("empty result without the populate query", func(t *testing.T) {
+		db, connector := newFakeDB(fakeQuery{
+			prefix:  `SELECT product."id", COUNT(*) OVER() FROM product`,
+			columns: []string{"id", "count"},
+		})
+
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 2, Page: 1, Includes: []string{"variants"}})
+		require.NoError(t, err)
+
+		err = NewExecutor[string, productWithVariants](db, idField, fields).Find(context.Background(), resource)
+		require.NoError(t, err)
+		require.Len(t, connector.executions, 1)
+		require.Empty(t, resource.Response().Data.PaginatedResult)
+	})
+
+	t.Run("error if page out of range", func(t *testing.T) {
+		db, _ := newFakeDB(fakeQuery{
+			prefix:  `SELECT product."id", COUNT(*) OVER() FROM product`,
+			columns: []string{"id", "count"},
+		})
+
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 2, Page: 3})
+		require.NoError(t, err)
+
+		err = NewExecutor[string, productWithVariants](db, idField, fields).Find(context.Background(), resource)
+		require.ErrorIs(t, err, ErrPagination)
+	})
+}
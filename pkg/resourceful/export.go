@@ -0,0 +1,241 @@
+package resourceful
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export formats
+const (
+	CSV_EXPORT  = "csv"
+	XLSX_EXPORT = "xlsx"
+)
+
+// Exporter writes the QueryAndArgs result rows as the spreadsheet, the columns are the selected fields.
+// The rows can be written in batches (e.g. the server-side cursor FETCH), the Close method must be called at the end
+type Exporter struct {
+	fields []*Field
+	writer recordWriter
+}
+
+type recordWriter interface {
+	writeRecord(values []any) error
+	close() error
+}
+
+// Create the Exporter of the selected fields & write the header, the field key is the column header
+func (r *Resource[IDType, Model]) NewExporter(w io.Writer, format string) (*Exporter, error) {
+	if !r.isProcessed {
+		return nil, createError("SetParam method must be called")
+	}
+	if len(r.selectFields) == 0 {
+		return nil, createError("selected fields can't be empty. Use the Select method instead")
+	}
+
+	var writer recordWriter
+	switch format {
+	case CSV_EXPORT:
+		writer = newCSVWriter(w)
+	case XLSX_EXPORT:
+		xlsxWriter, err := newXLSXWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		writer = xlsxWriter
+	default:
+		return nil, createError(fmt.Sprintf(`invalid "%s" export format`, format))
+	}
+
+	var header []any
+	for _, field := range r.selectFields {
+		header = append(header, fieldKey(field))
+	}
+	err := writer.writeRecord(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Exporter{fields: r.selectFields, writer: writer}, nil
+}
+
+// Write the result rows, return the number of the written rows
+func (e *Exporter) WriteRows(rows Rows) (int, error) {
+	var count int
+
+	for rows.Next() {
+		var dest []any
+		for _, field := range e.fields {
+			dest = append(dest, scanDestination(field.Type))
+		}
+
+		err := rows.Scan(dest...)
+		if err != nil {
+			return count, err
+		}
+
+		values := make([]any, 0, len(dest))
+		for _, value := range dest {
+			values = append(values, scannedValue(value))
+		}
+
+		err = e.writer.writeRecord(values)
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, rows.Err()
+}
+
+// Flush the buffered rows & finish the file
+func (e *Exporter) Close() error {
+	return e.writer.close()
+}
+
+func exportText(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case bool:
+		return strconv.FormatBool(value)
+	case time.Time:
+		return value.Format(time.RFC3339)
+	}
+
+	return fmt.Sprint(value)
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) writeRecord(values []any) error {
+	record := make([]string, 0, len(values))
+	for _, value := range values {
+		text := exportText(value)
+
+		// Prevent the formula injection when the file is opened by the spreadsheet application
+		if _, ok := value.(string); ok && text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+			text = "'" + text
+		}
+
+		record = append(record, text)
+	}
+
+	return c.writer.Write(record)
+}
+
+func (c *csvWriter) close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+// The minimal single sheet workbook, the sheet is the last zip entry so the rows are streamed without buffering
+type xlsxWriter struct {
+	zipWriter *zip.Writer
+	sheet     *bufio.Writer
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	zipWriter := zip.NewWriter(w)
+
+	staticFiles := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRelationships},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRelationships},
+	}
+	for _, staticFile := range staticFiles {
+		fileWriter, err := zipWriter.Create(staticFile.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(fileWriter, staticFile.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetWriter, err := zipWriter.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(sheetWriter)
+	if _, err := sheet.WriteString(xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{zipWriter: zipWriter, sheet: sheet}, nil
+}
+
+func (x *xlsxWriter) writeRecord(values []any) error {
+	x.sheet.WriteString("<row>")
+	for _, value := range values {
+		switch value := value.(type) {
+		case nil:
+			x.sheet.WriteString("<c/>")
+		case float64, int64:
+			x.sheet.WriteString("<c><v>" + exportText(value) + "</v></c>")
+		case bool:
+			boolValue := "0"
+			if value {
+				boolValue = "1"
+			}
+			x.sheet.WriteString(`<c t="b"><v>` + boolValue + "</v></c>")
+		default:
+			x.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			if err := xml.EscapeText(x.sheet, []byte(exportText(value))); err != nil {
+				return err
+			}
+			x.sheet.WriteString("</t></is></c>")
+		}
+	}
+	_, err := x.sheet.WriteString("</row>")
+
+	return err
+}
+
+func (x *xlsxWriter) close() error {
+	if _, err := x.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return x.zipWriter.Close()
+}
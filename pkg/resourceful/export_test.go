@@ -0,0 +1,102 @@
+package resourceful
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_NewExporter(t *testing.T) {
+	productDefinition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+
+	t.Run("error if SetParam is not called", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+
+		_, err := resource.NewExporter(&bytes.Buffer{}, CSV_EXPORT)
+		require.Error(t, err)
+	})
+
+	t.Run("error if fields are not selected", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1})
+		require.NoError(t, err)
+
+		_, err = resource.NewExporter(&bytes.Buffer{}, CSV_EXPORT)
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid format", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1})
+		require.NoError(t, err)
+		resource.Select([]*Field{productTable.Field("id")})
+
+		_, err = resource.NewExporter(&bytes.Buffer{}, "pdf")
+		require.Error(t, err)
+	})
+}
+
+func TestExporter_WriteRows(t *testing.T) {
+	productDefinition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+
+	newExporter := func(w io.Writer, format string) *Exporter {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1})
+		require.NoError(t, err)
+		resource.Select([]*Field{productTable.Field("id"), productTable.Field("name"), productTable.Field("count")})
+
+		exporter, err := resource.NewExporter(w, format)
+		require.NoError(t, err)
+
+		return exporter
+	}
+
+	t.Run("write csv in batches", func(t *testing.T) {
+		var buffer bytes.Buffer
+		exporter := newExporter(&buffer, CSV_EXPORT)
+
+		count, err := exporter.WriteRows(&fakeRows{rows: [][]any{
+			{"a", "apple", 1.5},
+			{"b", "=SUM(A1)", nil},
+		}})
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		count, err = exporter.WriteRows(&fakeRows{rows: [][]any{{"c", "cherry", int64(3)}}})
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		require.NoError(t, exporter.Close())
+		require.Equal(t, "id,name,count\na,apple,1.5\nb,'=SUM(A1),\nc,cherry,3\n", buffer.String())
+	})
+
+	t.Run("write xlsx", func(t *testing.T) {
+		var buffer bytes.Buffer
+		exporter := newExporter(&buffer, XLSX_EXPORT)
+
+		_, err := exporter.WriteRows(&fakeRows{rows: [][]any{{"a", "apple & <pear>", 1.5}}})
+		require.NoError(t, err)
+		require.NoError(t, exporter.Close())
+
+		zipReader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		require.NoError(t, err)
+
+		var sheet []byte
+		for _, file := range zipReader.File {
+			if file.Name == "xl/worksheets/sheet1.xml" {
+				fileReader, err := file.Open()
+				require.NoError(t, err)
+				sheet, err = io.ReadAll(fileReader)
+				require.NoError(t, err)
+			}
+		}
+		require.Contains(t, string(sheet), `<row><c t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+		require.Contains(t, string(sheet), `<t xml:space="preserve">apple &amp; &lt;pear&gt;</t>`)
+		require.Contains(t, string(sheet), `<c><v>1.5</v></c></row></sheetData></worksheet>`)
+	})
+}
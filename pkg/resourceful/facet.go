@@ -0,0 +1,226 @@
+package resourceful
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Maximum number of values per facet, the values are ordered by the count
+const facetValueLimit = 50
+
+var placeholderRegexp = regexp.MustCompile(`\$(\d+)`)
+
+type FacetValue struct {
+	Value any   `json:"value"`
+	Count int64 `json:"count"`
+}
+
+// FacetRange is the value of the range facet, the From is inclusive & the To is exclusive.
+// The nil From is the range below the first bound & the nil To is the range from the last bound
+type FacetRange struct {
+	From *float64 `json:"from"`
+	To   *float64 `json:"to"`
+}
+
+// Return the Facetable fields requested by the Parameter facets
+func (r *Resource[IDType, Model]) FacetFields() []*Field {
+	return r.facetFields
+}
+
+// Get the facet query & args of the field, the result rows are the field value followed by the count.
+// The range facet field is grouped by the FacetRanges bucket instead of the value.
+// The facet is computed under the search, scopes and all the filters except the filters on the field itself.
+// Use the ScanFacetRows method to read the result rows
+func (r *Resource[IDType, Model]) FacetQueryAndArgs(field *Field) (string, []any, error) {
+	if !r.isProcessed {
+		return "", nil, createError("SetParam method must be called")
+	}
+	if field == nil || !r.isFacetField(field) {
+		return "", nil, createError("facet field must be requested by the Parameter facets")
+	}
+
+	var queryStatement string
+
+	// Select Statement
+	facetStatement := field.statement
+	if len(field.FacetRanges) > 0 {
+		facetStatement = rangeFacetStatement(field)
+	}
+	queryStatement += fmt.Sprintf("SELECT %s, COUNT(*) FROM %s", facetStatement, r.tableDefinition.statement)
+
+	// Join Statements
+	joinStatements := r.getJoinStatements([]string{FACET, SEARCH, FILTER, OPTIONAL_FILTER, MANDATORY})
+	if len(joinStatements) > 0 {
+		queryStatement += "\n" + strings.Join(joinStatements, "\n")
+	}
+
+	// Where Statement, exclude the filters on the facet field
+	var whereStatements []string
+	for key, whereStatement := range r.whereStatements {
+		if !containsField(r.filterFieldsMap[key], field) {
+			whereStatements = append(whereStatements, whereStatement)
+		}
+	}
+	whereStatements, queryArgs := rebindStatements(whereStatements, r.queryArgs, nil)
+	whereStatements = append(whereStatements, r.defaultWhereStatements...)
+	if len(whereStatements) > 0 {
+		queryStatement += "\nWHERE " + strings.Join(whereStatements, "\nAND ")
+	}
+
+	// Group By & Sort Statement, the range facet is ordered by the range & has at most one bucket more than the bounds
+	queryStatement += "\nGROUP BY " + facetStatement
+	if len(field.FacetRanges) > 0 {
+		queryStatement += fmt.Sprintf("\nORDER BY %s ASC", facetStatement)
+	} else {
+		queryStatement += fmt.Sprintf("\nORDER BY COUNT(*) DESC, %s ASC", field.statement)
+		queryStatement += fmt.Sprintf("\nLIMIT %d", facetValueLimit)
+	}
+
+	return queryStatement, queryArgs, nil
+}
+
+// Read the FacetQueryAndArgs result rows into the Resource facets, the facets are returned on the Response
+func (r *Resource[IDType, Model]) ScanFacetRows(field *Field, rows Rows) error {
+	if field == nil || !r.isFacetField(field) {
+		return createError("facet field must be requested by the Parameter facets")
+	}
+
+	facetValues := make([]FacetValue, 0)
+	for rows.Next() {
+		var count int64
+		value := scanDestination(field.Type)
+		if len(field.FacetRanges) > 0 {
+			value = new(sql.NullInt64)
+		}
+
+		err := rows.Scan(value, &count)
+		if err != nil {
+			return err
+		}
+
+		facetValue := scannedValue(value)
+		if bucket, ok := facetValue.(int64); ok && len(field.FacetRanges) > 0 {
+			facetValue = facetRange(field.FacetRanges, bucket)
+		}
+
+		facetValues = append(facetValues, FacetValue{Value: facetValue, Count: count})
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if r.facets == nil {
+		r.facets = make(map[string][]FacetValue)
+	}
+	r.facets[r.facetKeys[field]] = facetValues
+
+	return nil
+}
+
+// The width_bucket of the field by the FacetRanges, the bucket 0 is below the first bound & the NULL value has no bucket
+func rangeFacetStatement(field *Field) string {
+	bounds := make([]string, 0, len(field.FacetRanges))
+	for _, bound := range field.FacetRanges {
+		bounds = append(bounds, strconv.FormatFloat(bound, 'f', -1, 64))
+	}
+
+	return fmt.Sprintf("width_bucket(%s::numeric, ARRAY[%s]::numeric[])", field.statement, strings.Join(bounds, ", "))
+}
+
+func facetRange(bounds []float64, bucket int64) FacetRange {
+	var facetRange FacetRange
+	if bucket > 0 && int(bucket) <= len(bounds) {
+		from := bounds[bucket-1]
+		facetRange.From = &from
+	}
+	if bucket >= 0 && int(bucket) < len(bounds) {
+		to := bounds[bucket]
+		facetRange.To = &to
+	}
+
+	return facetRange
+}
+
+func (r *Resource[IDType, Model]) processFacets(facetParams []string) error {
+	var (
+		validationErrors ValidationErrors
+		facetFields      []*Field
+		facetKeys        map[*Field]string
+	)
+	facetKeys = make(map[*Field]string)
+
+	for key, facetParam := range facetParams {
+		facetKey := strings.TrimSpace(facetParam)
+
+		// Validate Field
+		var (
+			field *Field
+			ok    bool
+		)
+		if field, ok = r.fieldsMap[facetKey]; !ok || !field.Facetable {
+			validationErrors.appendFieldError(fmt.Sprintf("facets.%d", (key+1)), "invalid field")
+			continue
+		}
+		if _, ok := facetKeys[field]; ok {
+			validationErrors.appendFieldError(fmt.Sprintf("facets.%d", (key+1)), fmt.Sprintf(`duplicate with "%s" field`, facetKey))
+			continue
+		}
+
+		facetKeys[field] = facetKey
+		facetFields = append(facetFields, field)
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	for _, field := range facetFields {
+		r.useFieldFor(field, FACET)
+	}
+	r.facetFields = facetFields
+	r.facetKeys = facetKeys
+
+	return nil
+}
+
+func (r *Resource[IDType, Model]) isFacetField(field *Field) bool {
+	_, ok := r.facetKeys[field]
+	return ok
+}
+
+func containsField(fields []*Field, target *Field) bool {
+	for _, field := range fields {
+		if field == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Renumber the statements placeholders by the order of use & drop the unused args,
+// the used args are appended to the reboundArgs
+func rebindStatements(statements []string, args []any, reboundArgs []any) ([]string, []any) {
+	var reboundStatements []string
+	newPlaceholders := make(map[int]int)
+
+	for _, statement := range statements {
+		reboundStatement := placeholderRegexp.ReplaceAllStringFunc(statement, func(placeholder string) string {
+			index, _ := strconv.Atoi(placeholder[1:])
+			if _, ok := newPlaceholders[index]; !ok {
+				reboundArgs = append(reboundArgs, args[index-1])
+				newPlaceholders[index] = len(reboundArgs)
+			}
+
+			return fmt.Sprintf("$%d", newPlaceholders[index])
+		})
+
+		reboundStatements = append(reboundStatements, reboundStatement)
+	}
+
+	return reboundStatements, reboundArgs
+}
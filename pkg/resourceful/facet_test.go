@@ -0,0 +1,194 @@
+package resourceful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_processFacets(t *testing.T) {
+	t.Run("error if field is not facetable", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFacets([]string{"name"})
+		require.Error(t, err)
+
+		err = productResource.processFacets([]string{"random_field"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if has duplicate field", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFacets([]string{"count", "count"})
+		require.Error(t, err)
+	})
+
+	t.Run("no error if valid", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Limit: 10, Page: 1, Facets: []string{"count", "product_type.name"}})
+		require.NoError(t, err)
+		require.Equal(t, []*Field{productTable.Field("count"), productTypeTable.Field("name")}, productResource.FacetFields())
+	})
+}
+
+func TestResource_FacetQueryAndArgs(t *testing.T) {
+	productDefinition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+
+	t.Run("error if field is not requested", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1})
+		require.NoError(t, err)
+
+		_, _, err = resource.FacetQueryAndArgs(productTable.Field("count"))
+		require.Error(t, err)
+	})
+
+	t.Run("exclude the filters on the facet field", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.Scope(productTable.Field("company_id"), EQ, 392)
+		require.NoError(t, err)
+		err = resource.SetParam(Parameter{
+			Limit:   10,
+			Page:    1,
+			Filters: []string{"count gt 2 OR name eq apple", "product_type.name eq fruit"},
+			Facets:  []string{"count", "product_type.name"},
+		})
+		require.NoError(t, err)
+
+		query, args, err := resource.FacetQueryAndArgs(productTable.Field("count"))
+		require.NoError(t, err)
+		require.Equal(t, `SELECT product."count", COUNT(*) FROM product
+JOIN product_type pt ON product."product_type_id" = pt."id"
+WHERE pt."name" = $1
+AND product."company_id" = $2
+AND product."is_deleted" is false
+GROUP BY product."count"
+ORDER BY COUNT(*) DESC, product."count" ASC
+LIMIT 50`, query)
+		require.Equal(t, []any{"fruit", 392}, args)
+
+		query, args, err = resource.FacetQueryAndArgs(productTypeTable.Field("name"))
+		require.NoError(t, err)
+		require.Equal(t, `SELECT pt."name", COUNT(*) FROM product
+JOIN product_type pt ON product."product_type_id" = pt."id"
+WHERE (product."count" > $1 OR product."name" = $2)
+AND product."company_id" = $3
+AND product."is_deleted" is false
+GROUP BY pt."name"
+ORDER BY COUNT(*) DESC, pt."name" ASC
+LIMIT 50`, query)
+		require.Equal(t, []any{"2", "apple", 392}, args)
+	})
+
+	t.Run("keep the local filters on the facet field", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.SetParam(Parameter{
+			Limit:        10,
+			Page:         1,
+			Filters:      []string{"count lt 10"},
+			LocalFilters: []string{"count gt 1"},
+			Facets:       []string{"count"},
+		})
+		require.NoError(t, err)
+
+		query, args, err := resource.FacetQueryAndArgs(productTable.Field("count"))
+		require.NoError(t, err)
+		require.Equal(t, `SELECT product."count", COUNT(*) FROM product
+JOIN product_type pt ON product."product_type_id" = pt."id"
+WHERE product."count" > $1
+AND product."is_deleted" is false
+GROUP BY product."count"
+ORDER BY COUNT(*) DESC, product."count" ASC
+LIMIT 50`, query)
+		require.Equal(t, []any{"1"}, args)
+	})
+
+	t.Run("scan rows into the response facets", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Facets: []string{"count"}})
+		require.NoError(t, err)
+
+		err = resource.ScanFacetRows(productTable.Field("count"), &fakeRows{rows: [][]any{
+			{"2", int64(5)},
+			{nil, int64(1)},
+		}})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]FacetValue{
+			"count": {{Value: float64(2), Count: 5}, {Value: nil, Count: 1}},
+		}, resource.Response().Facets)
+	})
+}
+
+func TestResource_RangeFacet(t *testing.T) {
+	newRangeTable := func() *Table {
+		return &Table{
+			Name: "item",
+			Fields: []*Field{
+				{Name: "id"},
+				{Name: "name", Type: STRING, Searchable: true},
+				{Name: "price", Type: NUMERIC, Filterable: true, Facetable: true, FacetRanges: []float64{1000, 5000.5}},
+			},
+		}
+	}
+
+	t.Run("error if invalid facet ranges", func(t *testing.T) {
+		table := newRangeTable()
+		table.Field("price").FacetRanges = []float64{5000, 1000}
+		_, err := NewDefinition(table)
+		require.Error(t, err)
+
+		table = newRangeTable()
+		table.Field("price").Facetable = false
+		_, err = NewDefinition(table)
+		require.Error(t, err)
+
+		table = newRangeTable()
+		table.Field("name").FacetRanges = []float64{1000}
+		_, err = NewDefinition(table)
+		require.Error(t, err)
+	})
+
+	rangeTable := newRangeTable()
+	definition, err := NewDefinition(rangeTable)
+	require.NoError(t, err)
+
+	t.Run("group by the range bucket", func(t *testing.T) {
+		resource := NewResource[string, string](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Filters: []string{"price gt 10"}, Facets: []string{"price"}})
+		require.NoError(t, err)
+
+		query, args, err := resource.FacetQueryAndArgs(rangeTable.Field("price"))
+		require.NoError(t, err)
+		require.Equal(t, `SELECT width_bucket(item."price"::numeric, ARRAY[1000, 5000.5]::numeric[]), COUNT(*) FROM item
+GROUP BY width_bucket(item."price"::numeric, ARRAY[1000, 5000.5]::numeric[])
+ORDER BY width_bucket(item."price"::numeric, ARRAY[1000, 5000.5]::numeric[]) ASC`, query)
+		require.Empty(t, args)
+	})
+
+	t.Run("scan the bucket into the range", func(t *testing.T) {
+		resource := NewResource[string, string](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Facets: []string{"price"}})
+		require.NoError(t, err)
+
+		err = resource.ScanFacetRows(rangeTable.Field("price"), &fakeRows{rows: [][]any{
+			{int64(0), int64(2)},
+			{int64(1), int64(5)},
+			{int64(2), int64(3)},
+			{nil, int64(1)},
+		}})
+		require.NoError(t, err)
+
+		low, high := float64(1000), 5000.5
+		require.Equal(t, map[string][]FacetValue{
+			"price": {
+				{Value: FacetRange{To: &low}, Count: 2},
+				{Value: FacetRange{From: &low, To: &high}, Count: 5},
+				{Value: FacetRange{From: &high}, Count: 3},
+				{Value: nil, Count: 1},
+			},
+		}, resource.Response().Facets)
+	})
+}
@@ -0,0 +1,323 @@
+package resourceful
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter expression keywords, only the uppercase keywords are recognized
+// so lowercase words can still be used as an unquoted filter value
+const (
+	AND_EXPRESSION = "AND"
+	OR_EXPRESSION  = "OR"
+	NOT_EXPRESSION = "NOT"
+)
+
+// Filter expression grammar:
+//
+//	expression := and ( "OR" and )*
+//	and        := not ( "AND" not )*
+//	not        := "NOT" not | primary
+//	primary    := "(" expression ")" | condition
+//	condition  := field unary_operator | field operator value
+//	value      := "(" values ")" | values
+type filterNode struct {
+	// AND, OR, NOT or empty for the condition node
+	expression string
+	children   []*filterNode
+
+	// Condition node
+	key      string
+	operator string
+	value    string
+	position int
+}
+
+type filterToken struct {
+	value string
+	start int
+	end   int
+}
+
+type filterExpressionError struct {
+	message  string
+	position int
+}
+
+func (e *filterExpressionError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.message, e.position)
+}
+
+func newFilterExpressionError(message string, position int) error {
+	return &filterExpressionError{message: message, position: position}
+}
+
+type filterParser struct {
+	source string
+	tokens []filterToken
+	index  int
+}
+
+// Parse the filter expression into the filter AST, the error position is the 1-based position in the expression
+func parseFilterExpression(source string) (*filterNode, error) {
+	tokens, err := tokenizeFilterExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, newFilterExpressionError("invalid format", 1)
+	}
+
+	parser := &filterParser{source: source, tokens: tokens}
+	node, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token, ok := parser.peek(); ok {
+		return nil, newFilterExpressionError(fmt.Sprintf(`unexpected "%s"`, token.value), token.start+1)
+	}
+
+	return node, nil
+}
+
+func tokenizeFilterExpression(source string) ([]filterToken, error) {
+	var tokens []filterToken
+
+	for lo := 0; lo < len(source); {
+		switch source[lo] {
+		case ' ', '\t', '\n':
+			lo++
+			continue
+		case '(', ')':
+			tokens = append(tokens, filterToken{value: source[lo : lo+1], start: lo, end: lo + 1})
+			lo++
+			continue
+		}
+
+		hi := lo
+		if quote := source[lo]; quote == '"' || quote == '\'' {
+			// The quoted value is closed by the quote followed by a separator
+			for hi = lo + 1; hi < len(source); hi++ {
+				if source[hi] == quote && (hi+1 == len(source) || strings.ContainsRune(" \t\n)", rune(source[hi+1]))) {
+					break
+				}
+			}
+			if hi == len(source) {
+				return nil, newFilterExpressionError("unterminated quoted value", lo+1)
+			}
+			hi++
+		} else {
+			for hi < len(source) && !strings.ContainsRune(" \t\n()", rune(source[hi])) {
+				hi++
+			}
+		}
+
+		tokens = append(tokens, filterToken{value: source[lo:hi], start: lo, end: hi})
+		lo = hi
+	}
+
+	return tokens, nil
+}
+
+func (p *filterParser) parseOr() (*filterNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*filterNode{node}
+	for p.peekValue(OR_EXPRESSION) {
+		p.index++
+
+		child, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	if len(children) == 1 {
+		return node, nil
+	}
+
+	return &filterNode{expression: OR_EXPRESSION, children: children}, nil
+}
+
+func (p *filterParser) parseAnd() (*filterNode, error) {
+	node, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*filterNode{node}
+	for p.peekValue(AND_EXPRESSION) {
+		p.index++
+
+		child, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	if len(children) == 1 {
+		return node, nil
+	}
+
+	return &filterNode{expression: AND_EXPRESSION, children: children}, nil
+}
+
+func (p *filterParser) parseNot() (*filterNode, error) {
+	if !p.peekValue(NOT_EXPRESSION) {
+		return p.parsePrimary()
+	}
+	p.index++
+
+	child, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &filterNode{expression: NOT_EXPRESSION, children: []*filterNode{child}}, nil
+}
+
+func (p *filterParser) parsePrimary() (*filterNode, error) {
+	token, ok := p.next()
+	if !ok {
+		return nil, newFilterExpressionError("unexpected end of expression", len(p.source)+1)
+	}
+
+	// Group
+	if token.value == "(" {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.peekValue(")") {
+			return nil, newFilterExpressionError("missing closing parenthesis", token.start+1)
+		}
+		p.index++
+
+		return node, nil
+	}
+
+	if isFilterSeparator(token) {
+		return nil, newFilterExpressionError(fmt.Sprintf(`unexpected "%s"`, token.value), token.start+1)
+	}
+
+	// Condition
+	node := &filterNode{key: token.value, position: token.start + 1}
+
+	operatorToken, ok := p.peek()
+	if !ok || isFilterSeparator(operatorToken) || operatorToken.value == "(" {
+		return nil, newFilterExpressionError("invalid format", node.position)
+	}
+	p.index++
+	node.operator = operatorToken.value
+
+	if isUnaryFilterOperator(node.operator) {
+		return node, nil
+	}
+
+	// Condition value
+	valueStart, valueEnd := -1, -1
+	if p.peekValue("(") {
+		openToken, _ := p.next()
+		valueStart = openToken.start
+
+		for {
+			valueToken, ok := p.next()
+			if !ok {
+				return nil, newFilterExpressionError("missing closing parenthesis", openToken.start+1)
+			}
+			if valueToken.value == "(" {
+				return nil, newFilterExpressionError(`unexpected "("`, valueToken.start+1)
+			}
+			if valueToken.value == ")" {
+				valueEnd = valueToken.end
+				break
+			}
+		}
+	} else {
+		for {
+			valueToken, ok := p.peek()
+			if !ok || isFilterSeparator(valueToken) || valueToken.value == "(" {
+				break
+			}
+			p.index++
+
+			if valueStart == -1 {
+				valueStart = valueToken.start
+			}
+			valueEnd = valueToken.end
+		}
+	}
+
+	if valueStart == -1 {
+		return nil, newFilterExpressionError("missing value", operatorToken.end+1)
+	}
+	node.value = p.source[valueStart:valueEnd]
+
+	return node, nil
+}
+
+// Return the condition keys of the filter AST
+func (node *filterNode) keys() []string {
+	if node.expression == "" {
+		return []string{node.key}
+	}
+
+	var keys []string
+	for _, child := range node.children {
+		keys = append(keys, child.keys()...)
+	}
+
+	return keys
+}
+
+// Get the condition nodes of the expression
+func (node *filterNode) conditions() []*filterNode {
+	if node.expression == "" {
+		return []*filterNode{node}
+	}
+
+	var conditions []*filterNode
+	for _, child := range node.children {
+		conditions = append(conditions, child.conditions()...)
+	}
+
+	return conditions
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.index >= len(p.tokens) {
+		return filterToken{}, false
+	}
+
+	return p.tokens[p.index], true
+}
+
+func (p *filterParser) peekValue(value string) bool {
+	token, ok := p.peek()
+	return ok && token.value == value
+}
+
+func (p *filterParser) next() (filterToken, bool) {
+	token, ok := p.peek()
+	if ok {
+		p.index++
+	}
+
+	return token, ok
+}
+
+func isFilterSeparator(token filterToken) bool {
+	switch token.value {
+	case ")", AND_EXPRESSION, OR_EXPRESSION, NOT_EXPRESSION:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package resourceful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_parseFilterExpression(t *testing.T) {
+	t.Run("single condition", func(t *testing.T) {
+		node, err := parseFilterExpression(`name in ("perabotan dan alat rumah tangga" hobi)`)
+		require.NoError(t, err)
+		require.Equal(t, &filterNode{key: "name", operator: "in", value: `("perabotan dan alat rumah tangga" hobi)`, position: 1}, node)
+	})
+
+	t.Run("precedence and grouping", func(t *testing.T) {
+		node, err := parseFilterExpression(`(name eq active OR count gt 100) AND NOT name eq "foo bar"`)
+		require.NoError(t, err)
+		require.Equal(t, &filterNode{
+			expression: AND_EXPRESSION,
+			children: []*filterNode{
+				{
+					expression: OR_EXPRESSION,
+					children: []*filterNode{
+						{key: "name", operator: "eq", value: "active", position: 2},
+						{key: "count", operator: "gt", value: "100", position: 20},
+					},
+				},
+				{
+					expression: NOT_EXPRESSION,
+					children: []*filterNode{
+						{key: "name", operator: "eq", value: `"foo bar"`, position: 42},
+					},
+				},
+			},
+		}, node)
+	})
+
+	t.Run("AND binds tighter than OR", func(t *testing.T) {
+		node, err := parseFilterExpression(`name eq a OR name eq b AND count gt 1`)
+		require.NoError(t, err)
+		require.Equal(t, OR_EXPRESSION, node.expression)
+		require.Equal(t, AND_EXPRESSION, node.children[1].expression)
+	})
+
+	t.Run("error with position", func(t *testing.T) {
+		testCases := map[string]string{
+			``:                           "invalid format at position 1",
+			`name`:                       "invalid format at position 1",
+			`name eq`:                    "missing value at position 8",
+			`(name eq a`:                 "missing closing parenthesis at position 1",
+			`name eq a)`:                 `unexpected ")" at position 10`,
+			`name eq a AND`:              "unexpected end of expression at position 14",
+			`name eq a OR OR count eq 1`: `unexpected "OR" at position 14`,
+			`name in (a (b))`:            `unexpected "(" at position 12`,
+			`name eq "unterminated`:      "unterminated quoted value at position 9",
+		}
+
+		for expression, message := range testCases {
+			_, err := parseFilterExpression(expression)
+			require.EqualError(t, err, message, expression)
+		}
+	})
+}
+
+func TestResource_filterExpressionStatement(t *testing.T) {
+	t.Run("render parenthesised statement", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFilters([]string{`(name eq active OR count gt 100) AND NOT product_type.name eq foo`})
+		require.NoError(t, err)
+		require.Equal(t, []string{`((product."name" = $1 OR product."count" > $2) AND NOT (pt."name" = $3))`}, productResource.whereStatements)
+		require.Equal(t, []any{"active", "100", "foo"}, productResource.queryArgs)
+		require.Equal(t,
+			map[*Table]map[string]bool{
+				&productTable:     {OPTIONAL_FILTER: true},
+				&productTypeTable: {OPTIONAL_FILTER: true},
+			},
+			productResource.usedTablesMap,
+		)
+	})
+
+	t.Run("error with the invalid field position", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFilters([]string{`name eq a OR is_deleted eq true`})
+		require.EqualError(t, err, "filters.1: invalid field at position 14")
+	})
+}
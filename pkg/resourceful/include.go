@@ -0,0 +1,282 @@
+package resourceful
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Collection is the to-many relation of the resource table (e.g. the product variants), requested by the Parameter
+// includes. The collection rows are loaded by a second batched query keyed by the paginated ids & nested into
+// the `include:"<name>"` slice field of each PaginatedResult element, the slice element is scanned by the db tags
+type Collection struct {
+	Name string
+	// The resource table field referenced by the collection, the Model must have the field with the same db tag
+	ReferenceKeyField *Field
+	// The collection table field referencing the ReferenceKeyField
+	ForeignKeyField *Field
+	Table           *Table
+
+	selectedFields         []*Field
+	defaultWhereStatements []string
+	defaultSortFields      []Field
+}
+
+// Init the collection tables of the resource table, the collection table is queried on its own so it can't have relations
+func (table *Table) initCollections() (map[string]*Collection, error) {
+	collectionsMap := make(map[string]*Collection)
+
+	for _, collection := range table.Collections {
+		if collection.Name == "" {
+			return nil, createError(fmt.Sprintf(`collection name on "%s" table can't be empty`, table.Name))
+		}
+		if _, ok := collectionsMap[collection.Name]; ok {
+			return nil, createError(fmt.Sprintf(`duplicate "%s" collection on "%s" table`, collection.Name, table.Name))
+		}
+		if collection.Table == nil {
+			return nil, createError(fmt.Sprintf(`"%s" collection table can't be empty`, collection.Name))
+		}
+		if len(collection.Table.Relations) > 0 || len(collection.Table.Collections) > 0 {
+			return nil, createError(fmt.Sprintf(`"%s" collection table can't have relations`, collection.Name))
+		}
+
+		_, _, defaultWhereStatements, defaultSortFields, _, err := collection.Table.init()
+		if err != nil {
+			return nil, err
+		}
+
+		if collection.ReferenceKeyField == nil || collection.ReferenceKeyField.table != table {
+			return nil, createError(fmt.Sprintf(`"%s" collection reference key field must be a field of "%s" table`, collection.Name, table.Name))
+		}
+		if collection.ForeignKeyField == nil || collection.ForeignKeyField.table != collection.Table {
+			return nil, createError(fmt.Sprintf(`"%s" collection foreign key field must be a field of "%s" table`, collection.Name, collection.Table.Name))
+		}
+
+		var selectedFields []*Field
+		for _, field := range collection.Table.Fields {
+			if field.Selectable {
+				selectedFields = append(selectedFields, field)
+			}
+		}
+		if len(selectedFields) == 0 {
+			return nil, createError(fmt.Sprintf(`"%s" collection must have at least one selectable field`, collection.Name))
+		}
+
+		collection.selectedFields = selectedFields
+		collection.defaultWhereStatements = defaultWhereStatements
+		collection.defaultSortFields = defaultSortFields
+		collectionsMap[collection.Name] = collection
+	}
+
+	return collectionsMap, nil
+}
+
+// Return the collections requested by the Parameter includes
+func (r *Resource[IDType, Model]) IncludedCollections() []*Collection {
+	return r.includedCollections
+}
+
+// Get the collection query & args of the paginated ids, the result rows are the foreign key followed by
+// the Selectable fields of the collection table. Use the ScanIncludeRows method to read the result rows
+func (r *Resource[IDType, Model]) IncludeQueryAndArgs(collection *Collection, ids []IDType) (string, []any, error) {
+	if !r.isProcessed {
+		return "", nil, createError("SetParam method must be called")
+	}
+	if collection == nil || !r.isIncludedCollection(collection) {
+		return "", nil, createError("collection must be requested by the Parameter includes")
+	}
+	if len(ids) == 0 {
+		return "", nil, createError("ids can't be empty")
+	}
+
+	var (
+		queryStatement    string
+		whereInStatements []string
+		queryArgs         []any
+	)
+
+	// Select Statement
+	selectStatements := []string{collection.ForeignKeyField.statement}
+	for _, field := range collection.selectedFields {
+		selectStatement := field.statement
+		if field.Expression != "" {
+			selectStatement = fmt.Sprintf(`%s AS "%s"`, field.statement, field.Name)
+		}
+
+		selectStatements = append(selectStatements, selectStatement)
+	}
+	queryStatement += fmt.Sprintf("SELECT %s FROM %s", strings.Join(selectStatements, ", "), collection.Table.statement)
+
+	// Where Statement
+	for _, id := range ids {
+		queryArgs = append(queryArgs, id)
+		whereInStatements = append(whereInStatements, fmt.Sprintf("$%d", len(queryArgs)))
+	}
+	whereStatements := []string{fmt.Sprintf("%s IN (%s)", collection.ForeignKeyField.statement, strings.Join(whereInStatements, ", "))}
+	whereStatements = append(whereStatements, collection.defaultWhereStatements...)
+	queryStatement += "\nWHERE " + strings.Join(whereStatements, "\nAND ")
+
+	// Sort Statement, grouped by the foreign key then the collection default sorts
+	sortStatements := []string{fmt.Sprintf("%s %s", collection.ForeignKeyField.statement, sortOperators["asc"])}
+	for _, defaultSortField := range collection.defaultSortFields {
+		sortStatements = append(sortStatements, fmt.Sprintf("%s %s", defaultSortField.statement, sortOperators[defaultSortField.Sort]))
+	}
+	queryStatement += "\nORDER BY " + strings.Join(sortStatements, ", ")
+
+	return queryStatement, queryArgs, nil
+}
+
+// Read the IncludeQueryAndArgs result rows & nest them into the `include:"<name>"` slice field of each
+// PaginatedResult element, matched by the ReferenceKeyField db tag. The SetResult method must be called first
+func (r *Resource[IDType, Model]) ScanIncludeRows(collection *Collection, rows Rows) error {
+	if collection == nil || !r.isIncludedCollection(collection) {
+		return createError("collection must be requested by the Parameter includes")
+	}
+	if r.result == nil {
+		return createError("SetResult method must be called")
+	}
+
+	modelType := reflect.TypeOf((*Model)(nil)).Elem()
+	if modelType.Kind() != reflect.Struct {
+		return createError("model must be a struct")
+	}
+
+	includeIndex, ok := structFieldIndex(modelType, "include", collection.Name)
+	if !ok || modelType.FieldByIndex(includeIndex).Type.Kind() != reflect.Slice ||
+		modelType.FieldByIndex(includeIndex).Type.Elem().Kind() != reflect.Struct {
+		return createError(fmt.Sprintf(`model must have the "%s" include slice field`, collection.Name))
+	}
+	referenceKeyIndex, ok := structFieldIndex(modelType, "db", collection.ReferenceKeyField.Name)
+	if !ok {
+		return createError(fmt.Sprintf(`model must have the "%s" db field`, collection.ReferenceKeyField.Name))
+	}
+
+	sliceType := modelType.FieldByIndex(includeIndex).Type
+	elemType := sliceType.Elem()
+	referenceKeyType := modelType.FieldByIndex(referenceKeyIndex).Type
+
+	// Map the reference key to the result elements, the included slice is empty rather than null
+	resultIndexes := make(map[any][]int)
+	for key := range r.result.PaginatedResult {
+		model := reflect.ValueOf(&r.result.PaginatedResult[key]).Elem()
+		model.FieldByIndex(includeIndex).Set(reflect.MakeSlice(sliceType, 0, 0))
+
+		if referenceKey, ok := indirectValue(model.FieldByIndex(referenceKeyIndex)); ok {
+			resultIndexes[referenceKey] = append(resultIndexes[referenceKey], key)
+		}
+	}
+
+	var elemIndexes [][]int
+	for _, field := range collection.selectedFields {
+		elemIndex, _ := structFieldIndex(elemType, "db", field.Name)
+		elemIndexes = append(elemIndexes, elemIndex)
+	}
+
+	for rows.Next() {
+		foreignKey := reflect.New(referenceKeyType)
+		elem := reflect.New(elemType).Elem()
+
+		dest := []any{foreignKey.Interface()}
+		for _, elemIndex := range elemIndexes {
+			// The field without the db tag on the element is discarded
+			if elemIndex == nil {
+				dest = append(dest, new(any))
+				continue
+			}
+
+			dest = append(dest, elem.FieldByIndex(elemIndex).Addr().Interface())
+		}
+
+		err := rows.Scan(dest...)
+		if err != nil {
+			return err
+		}
+
+		referenceKey, ok := indirectValue(foreignKey.Elem())
+		if !ok {
+			continue
+		}
+		for _, key := range resultIndexes[referenceKey] {
+			includeField := reflect.ValueOf(&r.result.PaginatedResult[key]).Elem().FieldByIndex(includeIndex)
+			includeField.Set(reflect.Append(includeField, elem))
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *Resource[IDType, Model]) processIncludes(includeParams []string) error {
+	var (
+		validationErrors    ValidationErrors
+		includedCollections []*Collection
+		usedIncludeKey      map[string]bool
+	)
+	usedIncludeKey = make(map[string]bool)
+
+	for key, includeParam := range includeParams {
+		includeKey := strings.TrimSpace(includeParam)
+
+		// Validate Collection
+		collection, ok := r.collectionsMap[includeKey]
+		if !ok {
+			validationErrors.appendFieldError(fmt.Sprintf("includes.%d", (key+1)), "invalid include")
+			continue
+		}
+		if _, ok := usedIncludeKey[includeKey]; ok {
+			validationErrors.appendFieldError(fmt.Sprintf("includes.%d", (key+1)), fmt.Sprintf(`duplicate with "%s" include`, includeKey))
+			continue
+		}
+
+		usedIncludeKey[includeKey] = true
+		includedCollections = append(includedCollections, collection)
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	r.includedCollections = includedCollections
+
+	return nil
+}
+
+func (r *Resource[IDType, Model]) isIncludedCollection(collection *Collection) bool {
+	for _, includedCollection := range r.includedCollections {
+		if includedCollection == collection {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get the index of the struct field by the tag value, the tag options (e.g. ",omitempty") are ignored
+func structFieldIndex(structType reflect.Type, tagKey string, tagValue string) ([]int, bool) {
+	for key := 0; key < structType.NumField(); key++ {
+		structField := structType.Field(key)
+		if !structField.IsExported() {
+			continue
+		}
+
+		if tag, _, _ := strings.Cut(structField.Tag.Get(tagKey), ","); tag == tagValue {
+			return structField.Index, true
+		}
+	}
+
+	return nil, false
+}
+
+// Get the comparable value of the struct field, the pointer is dereferenced & the nil pointer is not ok
+func indirectValue(value reflect.Value) (any, bool) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, false
+		}
+		value = value.Elem()
+	}
+	if !value.Type().Comparable() {
+		return nil, false
+	}
+
+	return value.Interface(), true
+}
@@ -0,0 +1,190 @@
+package resourceful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type variantList struct {
+	Name  string `db:"name"`
+	Price int64  `db:"price"`
+}
+
+type productWithVariants struct {
+	ID       string        `db:"id"`
+	Name     string        `db:"name"`
+	Variants []variantList `json:"variants,omitempty" include:"variants"`
+}
+
+func newVariantTables() (*Table, *Table) {
+	variantTable := &Table{
+		Name:  "product_variant",
+		Alias: "pv",
+		Fields: []*Field{
+			{Name: "id"},
+			{Name: "product_id"},
+			{Name: "name", Type: STRING, Selectable: true},
+			{Name: "price", Type: NUMERIC, Selectable: true, Sort: "asc"},
+			{Name: "is_deleted", SoftDeleteField: true},
+		},
+	}
+
+	variantProductTable := &Table{
+		Name: "product",
+		Fields: []*Field{
+			{Name: "id"},
+			{Name: "name", Type: STRING, Searchable: true, Selectable: true},
+		},
+	}
+	variantProductTable.Collections = []*Collection{
+		{
+			Name:              "variants",
+			Table:             variantTable,
+			ForeignKeyField:   variantTable.Field("product_id"),
+			ReferenceKeyField: variantProductTable.Field("id"),
+		},
+	}
+
+	return variantProductTable, variantTable
+}
+
+func TestTable_initCollections(t *testing.T) {
+	t.Run("error if foreign key field is not a field of the collection table", func(t *testing.T) {
+		variantProductTable, _ := newVariantTables()
+		variantProductTable.Collections[0].ForeignKeyField = variantProductTable.Field("id")
+
+		_, err := NewDefinition(variantProductTable)
+		require.Error(t, err)
+	})
+
+	t.Run("error if reference key field is not a field of the resource table", func(t *testing.T) {
+		variantProductTable, variantTable := newVariantTables()
+		variantProductTable.Collections[0].ReferenceKeyField = variantTable.Field("id")
+
+		_, err := NewDefinition(variantProductTable)
+		require.Error(t, err)
+	})
+
+	t.Run("error if has duplicate collection", func(t *testing.T) {
+		variantProductTable, _ := newVariantTables()
+		variantProductTable.Collections = append(variantProductTable.Collections, variantProductTable.Collections[0])
+
+		_, err := NewDefinition(variantProductTable)
+		require.Error(t, err)
+	})
+
+	t.Run("error if collection table has relations", func(t *testing.T) {
+		variantProductTable, variantTable := newVariantTables()
+		variantTable.Relations = []*Relation{
+			{Table: &productTypeTable, ForeignKeyField: variantTable.Field("id"), ReferenceKeyField: productTypeTable.Field("id")},
+		}
+
+		_, err := NewDefinition(variantProductTable)
+		require.Error(t, err)
+	})
+}
+
+func TestResource_processIncludes(t *testing.T) {
+	variantProductTable, _ := newVariantTables()
+	definition, err := NewDefinition(variantProductTable)
+	require.NoError(t, err)
+
+	t.Run("error if invalid include", func(t *testing.T) {
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Includes: []string{"random"}})
+		require.Error(t, err)
+		require.Equal(t, "includes.1", err.(ValidationErrors)[0].FieldName)
+	})
+
+	t.Run("error if has duplicate include", func(t *testing.T) {
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Includes: []string{"variants", "variants"}})
+		require.Error(t, err)
+		require.Equal(t, "includes.2", err.(ValidationErrors)[0].FieldName)
+	})
+
+	t.Run("no error if valid", func(t *testing.T) {
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Includes: []string{"variants"}})
+		require.NoError(t, err)
+		require.Equal(t, variantProductTable.Collections, resource.IncludedCollections())
+	})
+}
+
+func TestResource_IncludeQueryAndArgs(t *testing.T) {
+	variantProductTable, _ := newVariantTables()
+	definition, err := NewDefinition(variantProductTable)
+	require.NoError(t, err)
+	collection := variantProductTable.Collections[0]
+
+	t.Run("error if collection is not requested", func(t *testing.T) {
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1})
+		require.NoError(t, err)
+
+		_, _, err = resource.IncludeQueryAndArgs(collection, []string{"a"})
+		require.Error(t, err)
+	})
+
+	t.Run("batch query keyed by the ids", func(t *testing.T) {
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "apple", Includes: []string{"variants"}})
+		require.NoError(t, err)
+
+		query, args, err := resource.IncludeQueryAndArgs(collection, []string{"a", "b"})
+		require.NoError(t, err)
+		require.Equal(t, `SELECT pv."product_id", pv."name", pv."price" FROM product_variant pv
+WHERE pv."product_id" IN ($1, $2)
+AND pv."is_deleted" is false
+ORDER BY pv."product_id" ASC, pv."price" ASC`, query)
+		require.Equal(t, []any{"a", "b"}, args)
+	})
+}
+
+func TestResource_ScanIncludeRows(t *testing.T) {
+	variantProductTable, _ := newVariantTables()
+	definition, err := NewDefinition(variantProductTable)
+	require.NoError(t, err)
+	collection := variantProductTable.Collections[0]
+
+	t.Run("error if result is not set", func(t *testing.T) {
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Includes: []string{"variants"}})
+		require.NoError(t, err)
+
+		err = resource.ScanIncludeRows(collection, &fakeRows{})
+		require.Error(t, err)
+	})
+
+	t.Run("error if model has no include field", func(t *testing.T) {
+		resource := NewResource[string, variantList](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Includes: []string{"variants"}})
+		require.NoError(t, err)
+		resource.SetResult(Result[string, variantList]{Ids: []string{"a"}, PaginatedResult: []variantList{{Name: "apple"}}})
+
+		err = resource.ScanIncludeRows(collection, &fakeRows{})
+		require.Error(t, err)
+	})
+
+	t.Run("nest the rows into each result", func(t *testing.T) {
+		resource := NewResource[string, productWithVariants](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Includes: []string{"variants"}})
+		require.NoError(t, err)
+		resource.SetResult(Result[string, productWithVariants]{
+			Ids:             []string{"a", "b"},
+			PaginatedResult: []productWithVariants{{ID: "a", Name: "apple"}, {ID: "b", Name: "banana"}},
+		})
+
+		err = resource.ScanIncludeRows(collection, &fakeRows{rows: [][]any{
+			{"a", "small", int64(10)},
+			{"a", "large", int64(20)},
+			{"c", "unknown", int64(30)},
+		}})
+		require.NoError(t, err)
+
+		result := resource.Response().Data.PaginatedResult
+		require.Equal(t, []variantList{{Name: "small", Price: 10}, {Name: "large", Price: 20}}, result[0].Variants)
+		require.Equal(t, []variantList{}, result[1].Variants)
+	})
+}
@@ -0,0 +1,309 @@
+package resourceful
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefinitionMetadata is the machine-readable description of the Definition, e.g. for the frontend to build the
+// filter & sort controls without guessing the table definition
+type DefinitionMetadata struct {
+	SearchStrategy string          `json:"search_strategy"`
+	SearchFields   []string        `json:"search_fields"`
+	DefaultSorts   []string        `json:"default_sorts"`
+	Fields         []FieldMetadata `json:"fields"`
+	Includes       []string        `json:"includes"`
+	Views          []View          `json:"views"`
+}
+
+type FieldMetadata struct {
+	Key         string    `json:"key"`
+	Type        string    `json:"type"`
+	Values      []string  `json:"values,omitempty"`
+	Filterable  bool      `json:"filterable"`
+	Sortable    bool      `json:"sortable"`
+	Selectable  bool      `json:"selectable"`
+	Facetable   bool      `json:"facetable"`
+	FacetRanges []float64 `json:"facet_ranges,omitempty"`
+	Searchable  bool      `json:"searchable"`
+	Operators   []string  `json:"operators"`
+}
+
+// Describe the fields available to the Parameter, the local filter only field is not part of the metadata.
+// The fields are sorted by the key
+func (d *Definition) Metadata() DefinitionMetadata {
+	metadata := DefinitionMetadata{
+		SearchStrategy: d.searchStrategy,
+		SearchFields:   []string{},
+		DefaultSorts:   []string{},
+		Fields:         []FieldMetadata{},
+		Includes:       []string{},
+		Views:          d.Views(),
+	}
+
+	searchFieldsMap := make(map[*Field]bool)
+	for _, searchField := range d.searchFields {
+		searchFieldsMap[searchField] = true
+	}
+	metadata.SearchFields = append(metadata.SearchFields, d.searchFieldKeys()...)
+
+	for _, defaultSortField := range d.defaultSortFields {
+		metadata.DefaultSorts = append(metadata.DefaultSorts, fmt.Sprintf("%s %s", d.keyOf(&defaultSortField), defaultSortField.Sort))
+	}
+
+	for _, key := range d.fieldKeys(func(field *Field) bool {
+		return field.Filterable || field.PatternFilterable || field.Sortable || field.Selectable || field.Facetable
+	}) {
+		field := d.fieldsMap[key]
+		metadata.Fields = append(metadata.Fields, FieldMetadata{
+			Key:         key,
+			Type:        field.Type,
+			Values:      field.Values,
+			Filterable:  field.Filterable || field.PatternFilterable,
+			Sortable:    field.Sortable,
+			Selectable:  field.Selectable,
+			Facetable:   field.Facetable,
+			FacetRanges: field.FacetRanges,
+			Searchable:  searchFieldsMap[field],
+			Operators:   fieldFilterOperators(field),
+		})
+	}
+
+	metadata.Includes = append(metadata.Includes, d.collectionNames()...)
+
+	return metadata
+}
+
+func (d *Definition) searchFieldKeys() []string {
+	var keys []string
+	for _, searchField := range d.searchFields {
+		keys = append(keys, d.keyOf(searchField))
+	}
+
+	return keys
+}
+
+func (d *Definition) collectionNames() []string {
+	var names []string
+	for name := range d.collectionsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Return the sorted keys of the fields matched by the filter
+func (d *Definition) fieldKeys(filter func(field *Field) bool) []string {
+	var keys []string
+	for key, field := range d.fieldsMap {
+		if filter(field) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Get the Parameter key of the field, matched by the statement since the default sort field is a copy
+func (d *Definition) keyOf(field *Field) string {
+	for key, mapField := range d.fieldsMap {
+		if mapField.statement == field.statement {
+			return key
+		}
+	}
+
+	return fieldKey(field)
+}
+
+// The filter operators available on the field, grouped by the operator kind
+func fieldFilterOperators(field *Field) []string {
+	operators := []string{}
+
+	if field.Filterable && field.Type == JSONB {
+		for _, operator := range sortedOperators(filterOperators) {
+			if jsonbPathFilterOperators[operator] {
+				operators = append(operators, operator)
+			}
+		}
+		operators = append(operators, sortedOperators(multiValueFilterOperators)...)
+		operators = append(operators, sortedOperators(unaryFilterOperators)...)
+	} else if field.Filterable {
+		operators = append(operators, sortedOperators(filterOperators)...)
+		operators = append(operators, sortedOperators(multiValueFilterOperators)...)
+		if isRangeType(field.Type) {
+			operators = append(operators, sortedOperators(rangeFilterOperators)...)
+		}
+		operators = append(operators, sortedOperators(unaryFilterOperators)...)
+	}
+	if field.PatternFilterable {
+		operators = append(operators, sortedOperators(patternFilterOperators)...)
+	}
+
+	return operators
+}
+
+func sortedOperators(operators map[string]string) []string {
+	keys := make([]string, 0, len(operators))
+	for key := range operators {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+type OpenAPIParameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description,omitempty"`
+	Schema      OpenAPISchema `json:"schema"`
+}
+
+type OpenAPISchema struct {
+	Type      string         `json:"type,omitempty"`
+	Enum      []string       `json:"enum,omitempty"`
+	Minimum   *int           `json:"minimum,omitempty"`
+	Maximum   *int           `json:"maximum,omitempty"`
+	MinLength *int           `json:"minLength,omitempty"`
+	MaxItems  *int           `json:"maxItems,omitempty"`
+	Default   any            `json:"default,omitempty"`
+	Items     *OpenAPISchema `json:"items,omitempty"`
+}
+
+// Generate the OpenAPI 3 query parameters of the Index endpoint, named by the Index query convention
+// (pagination, limit, page, cursor, search, filters, sort, fields, facets, include, include_deleted, only_deleted & view).
+// The parameter without any available field is omitted
+func (d *Definition) OpenAPIParameters() []OpenAPIParameter {
+	minimum, policy := 1, d.policy
+	parameters := []OpenAPIParameter{
+		{Name: "pagination", In: "query", Schema: OpenAPISchema{Type: "string", Enum: []string{PAGE_PAGINATION, CURSOR_PAGINATION}, Default: PAGE_PAGINATION}},
+		{Name: "limit", In: "query", Schema: OpenAPISchema{Type: "integer", Minimum: &minimum}},
+		{Name: "page", In: "query", Description: "Required on the page pagination", Schema: OpenAPISchema{Type: "integer", Minimum: &minimum}},
+		{Name: "cursor", In: "query", Description: "The next_cursor of the previous cursor page", Schema: OpenAPISchema{Type: "string"}},
+	}
+	if d.policy.MaxLimit > 0 {
+		parameters[1].Schema.Maximum = &policy.MaxLimit
+	}
+
+	if len(d.searchFields) > 0 {
+		parameters = append(parameters, OpenAPIParameter{
+			Name:        "search",
+			In:          "query",
+			Description: fmt.Sprintf(`Search the %s fields, the field is targeted by "key:value"`, strings.Join(d.searchFieldKeys(), ", ")),
+			Schema:      OpenAPISchema{Type: "string"},
+		})
+		if d.policy.MinSearchLength > 0 {
+			parameters[len(parameters)-1].Schema.MinLength = &policy.MinSearchLength
+		}
+	}
+
+	var filterDescriptions []string
+	for _, key := range d.fieldKeys(func(field *Field) bool { return field.Filterable || field.PatternFilterable }) {
+		filterDescriptions = append(filterDescriptions, fmt.Sprintf("%s (%s)", key, strings.Join(fieldFilterOperators(d.fieldsMap[key]), ", ")))
+	}
+	if len(filterDescriptions) > 0 {
+		parameters = append(parameters, OpenAPIParameter{
+			Name:        "filters",
+			In:          "query",
+			Description: fmt.Sprintf(`The "<field> <operator> <value>" filter expression of the %s fields`, strings.Join(filterDescriptions, ", ")),
+			Schema:      OpenAPISchema{Type: "array", Items: &OpenAPISchema{Type: "string"}},
+		})
+	}
+
+	var sorts []string
+	for _, key := range d.fieldKeys(func(field *Field) bool { return field.Sortable }) {
+		for _, sortOperator := range sortedOperators(sortOperators) {
+			sorts = append(sorts, fmt.Sprintf("%s %s", key, sortOperator))
+		}
+	}
+	parameters = appendEnumArrayParameter(parameters, "sort", sorts)
+	if len(sorts) > 0 && d.policy.MaxSorts > 0 {
+		parameters[len(parameters)-1].Schema.MaxItems = &policy.MaxSorts
+	}
+	parameters = appendEnumArrayParameter(parameters, "fields", d.fieldKeys(func(field *Field) bool { return field.Selectable }))
+	parameters = appendEnumArrayParameter(parameters, "facets", d.fieldKeys(func(field *Field) bool { return field.Facetable }))
+	parameters = appendEnumArrayParameter(parameters, "include", d.collectionNames())
+
+	// The soft delete modes of the SetSoftDeleteMode, only on the table with the soft delete field
+	if len(d.tableDefinition.softDeleteFields()) > 0 {
+		parameters = append(parameters,
+			OpenAPIParameter{Name: "include_deleted", In: "query", Description: "Include the soft deleted rows", Schema: OpenAPISchema{Type: "boolean"}},
+			OpenAPIParameter{Name: "only_deleted", In: "query", Description: "Only the soft deleted rows, can't be used with include_deleted", Schema: OpenAPISchema{Type: "boolean"}},
+		)
+	}
+
+	// The resource can add the view (e.g. the user saved view), so the view is not an enum
+	var viewNames []string
+	for _, view := range d.views {
+		viewNames = append(viewNames, view.Name)
+	}
+	viewParameter := OpenAPIParameter{Name: "view", In: "query", Schema: OpenAPISchema{Type: "string"}}
+	if len(viewNames) > 0 {
+		viewParameter.Description = fmt.Sprintf("The %s view or the saved view", strings.Join(viewNames, ", "))
+	}
+	parameters = append(parameters, viewParameter)
+
+	return parameters
+}
+
+func appendEnumArrayParameter(parameters []OpenAPIParameter, name string, enum []string) []OpenAPIParameter {
+	if len(enum) == 0 {
+		return parameters
+	}
+
+	return append(parameters, OpenAPIParameter{
+		Name:   name,
+		In:     "query",
+		Schema: OpenAPISchema{Type: "array", Items: &OpenAPISchema{Type: "string", Enum: enum}},
+	})
+}
+
+// OpenAPIDocument is the minimal OpenAPI 3 document of the resourceful Index endpoints
+type OpenAPIDocument struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    OpenAPIInfo                            `json:"info"`
+	Paths   map[string]map[string]OpenAPIOperation `json:"paths"`
+}
+
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenAPIOperation struct {
+	Summary    string                     `json:"summary,omitempty"`
+	Parameters []OpenAPIParameter         `json:"parameters"`
+	Responses  map[string]OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIResponse struct {
+	Description string `json:"description"`
+}
+
+// Create a new OpenAPIDocument
+func NewOpenAPIDocument(title, version string) *OpenAPIDocument {
+	return &OpenAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    OpenAPIInfo{Title: title, Version: version},
+		Paths:   make(map[string]map[string]OpenAPIOperation),
+	}
+}
+
+// Add the GET operation of the Index endpoint with the parameters of the definition
+func (o *OpenAPIDocument) AddIndexOperation(path, summary string, definition *Definition) {
+	if o.Paths[path] == nil {
+		o.Paths[path] = make(map[string]OpenAPIOperation)
+	}
+
+	o.Paths[path]["get"] = OpenAPIOperation{
+		Summary:    summary,
+		Parameters: definition.OpenAPIParameters(),
+		Responses: map[string]OpenAPIResponse{
+			"200": {Description: "OK"},
+			"400": {Description: "Invalid parameter"},
+		},
+	}
+}
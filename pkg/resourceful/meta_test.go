@@ -0,0 +1,103 @@
+package resourceful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinition_Metadata(t *testing.T) {
+	definition, err := NewDefinition(&productTable, DefinitionOption{Views: []View{{Name: "expensive", Filters: []string{"count gt 100"}}}})
+	require.NoError(t, err)
+
+	metadata := definition.Metadata()
+	require.Equal(t, LIKE_SEARCH, metadata.SearchStrategy)
+	require.Equal(t, []string{"name", "product_type.name"}, metadata.SearchFields)
+	require.Equal(t, []string{"created_on desc"}, metadata.DefaultSorts)
+	require.Equal(t, []string{}, metadata.Includes)
+	require.Equal(t, "expensive", metadata.Views[0].Name)
+
+	// The local filter only company_id field is not described
+	var keys []string
+	for _, field := range metadata.Fields {
+		keys = append(keys, field.Key)
+	}
+	require.Equal(t, []string{"count", "created_on", "name", "product_type.name"}, keys)
+
+	require.Equal(t, FieldMetadata{
+		Key:        "count",
+		Type:       NUMERIC,
+		Filterable: true,
+		Selectable: true,
+		Facetable:  true,
+		Operators:  []string{"eq", "gt", "gte", "lt", "lte", "ne", "in", "nin", "between", "isnull", "notnull"},
+	}, metadata.Fields[0])
+	require.Equal(t, FieldMetadata{
+		Key:        "name",
+		Type:       STRING,
+		Filterable: true,
+		Sortable:   true,
+		Selectable: true,
+		Searchable: true,
+		Operators:  []string{"eq", "gt", "gte", "lt", "lte", "ne", "in", "nin", "isnull", "notnull", "contains", "endswith", "ilike", "like", "startswith"},
+	}, metadata.Fields[2])
+}
+
+func TestDefinition_OpenAPIParameters(t *testing.T) {
+	definition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+
+	parametersMap := make(map[string]OpenAPIParameter)
+	for _, parameter := range definition.OpenAPIParameters() {
+		require.Equal(t, "query", parameter.In)
+		parametersMap[parameter.Name] = parameter
+	}
+
+	require.Equal(t, []string{PAGE_PAGINATION, CURSOR_PAGINATION}, parametersMap["pagination"].Schema.Enum)
+	require.Equal(t, "integer", parametersMap["limit"].Schema.Type)
+	require.Contains(t, parametersMap["search"].Description, "name, product_type.name")
+	require.Contains(t, parametersMap["filters"].Description, "count (eq, gt, gte, lt, lte, ne, in, nin, between, isnull, notnull)")
+	require.Equal(t, []string{"created_on asc", "created_on desc", "name asc", "name desc"}, parametersMap["sort"].Schema.Items.Enum)
+	require.Equal(t, []string{"count", "name"}, parametersMap["fields"].Schema.Items.Enum)
+	require.Equal(t, []string{"count", "product_type.name"}, parametersMap["facets"].Schema.Items.Enum)
+
+	require.Equal(t, "The next_cursor of the previous cursor page", parametersMap["cursor"].Description)
+	require.Equal(t, "boolean", parametersMap["include_deleted"].Schema.Type)
+	require.Equal(t, "boolean", parametersMap["only_deleted"].Schema.Type)
+
+	// No collection on the definition
+	_, ok := parametersMap["include"]
+	require.False(t, ok)
+
+	// No soft delete field on the table
+	typedDefinition, err := NewDefinition(newTypedTable())
+	require.NoError(t, err)
+	for _, parameter := range typedDefinition.OpenAPIParameters() {
+		require.NotContains(t, []string{"include_deleted", "only_deleted"}, parameter.Name)
+	}
+}
+
+func TestOpenAPIDocument_AddIndexOperation(t *testing.T) {
+	definition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+
+	document := NewOpenAPIDocument("service-demo", "1.0.0")
+	document.AddIndexOperation("/product", "List the products", definition)
+
+	require.Equal(t, "3.0.3", document.OpenAPI)
+	require.Equal(t, definition.OpenAPIParameters(), document.Paths["/product"]["get"].Parameters)
+}
+
+func TestDefinition_Metadata_Types(t *testing.T) {
+	definition, err := NewDefinition(newTypedTable())
+	require.NoError(t, err)
+
+	fieldsMap := make(map[string]FieldMetadata)
+	for _, field := range definition.Metadata().Fields {
+		fieldsMap[field.Key] = field
+	}
+
+	require.Equal(t, []string{"draft", "published"}, fieldsMap["status"].Values)
+	require.Equal(t, []string{"eq", "ne", "in", "nin", "isnull", "notnull"}, fieldsMap["attributes"].Operators)
+	require.Contains(t, fieldsMap["quantity"].Operators, "between")
+}
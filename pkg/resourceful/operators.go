@@ -1,11 +1,16 @@
 package resourceful
 
+import "strings"
+
 const (
-	SELECT    = "select"
-	SEARCH    = "search"
-	FILTER    = "filter"
-	SORT      = "sort"
-	MANDATORY = "mandatory"
+	SELECT          = "select"
+	SEARCH          = "search"
+	FILTER          = "filter"
+	OPTIONAL_FILTER = "optional_filter"
+	SORT            = "sort"
+	AGGREGATE       = "aggregate"
+	FACET           = "facet"
+	MANDATORY       = "mandatory"
 )
 
 var sortOperators = map[string]string{
@@ -23,7 +28,70 @@ var filterOperators = map[string]string{
 	//in string with quoted string separated by spaces
 }
 
+// Multi value operators, the values are separated by spaces & can be quoted
+var multiValueFilterOperators = map[string]string{
+	"in":  "in",
+	"nin": "not in",
+}
+
+// Range operators require exactly two values, only for NUMERIC & DATE field
+var rangeFilterOperators = map[string]string{
+	"between": "BETWEEN",
+}
+
+// Unary operators have no value
+var unaryFilterOperators = map[string]string{
+	"isnull":  "IS NULL",
+	"notnull": "IS NOT NULL",
+}
+
+// Pattern operators are only available on the PatternFilterable STRING field,
+// use "*" as the wildcard on like & ilike
+var patternFilterOperators = map[string]string{
+	"like":       "LIKE",
+	"ilike":      "ILIKE",
+	"startswith": "ILIKE",
+	"endswith":   "ILIKE",
+	"contains":   "ILIKE",
+}
+
+// The JSONB path value is compared as text, so only the equality operators are available
+var jsonbPathFilterOperators = map[string]bool{
+	"eq":      true,
+	"ne":      true,
+	"in":      true,
+	"nin":     true,
+	"isnull":  true,
+	"notnull": true,
+}
+
+var patternEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func validFilterOperator(filterOperator string) bool {
 	_, ok := filterOperators[filterOperator]
-	return ok || filterOperator == "in"
+	_, isPattern := patternFilterOperators[filterOperator]
+	_, isMultiValue := multiValueFilterOperators[filterOperator]
+	_, isRange := rangeFilterOperators[filterOperator]
+	return ok || isPattern || isMultiValue || isRange || isUnaryFilterOperator(filterOperator)
+}
+
+func isUnaryFilterOperator(filterOperator string) bool {
+	_, ok := unaryFilterOperators[filterOperator]
+	return ok
+}
+
+// Escape the LIKE special characters of the value & build the pattern by the operator
+func patternFilterValue(filterOperator, value string) string {
+	value = patternEscaper.Replace(value)
+
+	switch filterOperator {
+	case "startswith":
+		return value + "%"
+	case "endswith":
+		return "%" + value
+	case "contains":
+		return "%" + value + "%"
+	}
+
+	return strings.ReplaceAll(value, "*", "%")
 }
@@ -0,0 +1,145 @@
+package resourceful
+
+import (
+	"context"
+	"fmt"
+	"mceasy/service-demo/pkg/database"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Policy is the query cost guardrails of the Definition, the zero value of each policy is unlimited
+type Policy struct {
+	// The max Parameter limit
+	MaxLimit int
+	// The max number of the filter conditions (the conditions inside the filter expression & the view filters are counted)
+	MaxFilters int
+	// The max number of the Parameter sorts
+	MaxSorts int
+	// The min length of the free text & each targeted search term value
+	MinSearchLength int
+	// The min length of the leading wildcard pattern filter value (contains, endswith & like/ilike starting with "*"),
+	// the wildcards are not counted
+	MinPatternLength int
+	// The statement_timeout of the Resource queries, only applied inside the transaction
+	StatementTimeout time.Duration
+}
+
+// Validate the Parameter against the Definition policy, the violations are returned as the ValidationErrors
+func (r *Resource[IDType, Model]) processPolicy(param Parameter, view *View) error {
+	var validationErrors ValidationErrors
+
+	if r.policy.MaxLimit > 0 && param.Limit > r.policy.MaxLimit {
+		validationErrors.appendFieldError("limit", fmt.Sprintf("must be no greater than %d", r.policy.MaxLimit))
+	}
+
+	if r.policy.MaxFilters > 0 {
+		filterParams := append([]string{}, param.Filters...)
+		if view != nil {
+			filterParams = append(filterParams, view.Filters...)
+		}
+
+		// The invalid filter is reported by the processFilters
+		var conditionCount int
+		for _, filterParam := range filterParams {
+			filterNode, err := parseFilterExpression(filterParam)
+			if err == nil {
+				conditionCount += len(filterNode.keys())
+			}
+		}
+
+		if conditionCount > r.policy.MaxFilters {
+			validationErrors.appendFieldError("filters", fmt.Sprintf("must have no more than %d filter conditions", r.policy.MaxFilters))
+		}
+	}
+
+	if r.policy.MaxSorts > 0 && len(param.Sorts) > r.policy.MaxSorts {
+		validationErrors.appendFieldError("sorts", fmt.Sprintf("must have no more than %d sorts", r.policy.MaxSorts))
+	}
+
+	if r.policy.MinPatternLength > 0 {
+		var fieldNames, filterParams []string
+		for key, filterParam := range param.Filters {
+			fieldNames = append(fieldNames, fmt.Sprintf("filters.%d", key+1))
+			filterParams = append(filterParams, filterParam)
+		}
+		if view != nil {
+			for _, filterParam := range view.Filters {
+				fieldNames = append(fieldNames, "view")
+				filterParams = append(filterParams, filterParam)
+			}
+		}
+
+		for key, filterParam := range filterParams {
+			// The invalid filter is reported by the processFilters
+			filterNode, err := parseFilterExpression(filterParam)
+			if err != nil {
+				continue
+			}
+
+			for _, condition := range filterNode.conditions() {
+				if isShortLeadingWildcardPattern(condition, r.policy.MinPatternLength) {
+					validationErrors.appendFieldError(fieldNames[key], fmt.Sprintf("pattern must be at least %d characters at position %d", r.policy.MinPatternLength, condition.position))
+				}
+			}
+		}
+	}
+
+	if r.policy.MinSearchLength > 0 && param.Search != "" {
+		freeText, searchTerms := parseSearch(param.Search, r.isSearchKey)
+		if freeText != "" && utf8.RuneCountInString(freeText) < r.policy.MinSearchLength {
+			validationErrors.appendFieldError("search", fmt.Sprintf("must be at least %d characters", r.policy.MinSearchLength))
+		}
+		for _, searchTerm := range searchTerms {
+			if searchTerm.value != "" && utf8.RuneCountInString(searchTerm.value) < r.policy.MinSearchLength {
+				validationErrors.appendFieldError("search", fmt.Sprintf("must be at least %d characters at position %d", r.policy.MinSearchLength, searchTerm.position))
+			}
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	return nil
+}
+
+// Check if the condition is the leading wildcard pattern (the unanchored scan) shorter than the min length
+func isShortLeadingWildcardPattern(condition *filterNode, minLength int) bool {
+	filterValues, ok := sanitizeFilterValueByType(condition.value, STRING)
+	if !ok || len(filterValues) == 0 {
+		return false
+	}
+
+	value := filterValues[0]
+	switch condition.operator {
+	case "contains", "endswith":
+	case "like", "ilike":
+		if !strings.HasPrefix(value, "*") {
+			return false
+		}
+		value = strings.ReplaceAll(value, "*", "")
+	default:
+		return false
+	}
+
+	return utf8.RuneCountInString(value) < minLength
+}
+
+// Set the policy statement timeout on the transaction, the SET LOCAL is reset at the end of the transaction
+// & has no effect outside the transaction
+func (r *Resource[IDType, Model]) SetStatementTimeout(ctx context.Context, tx database.Queryer) error {
+	if r.policy.StatementTimeout <= 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", r.policy.StatementTimeout.Milliseconds()))
+	if err != nil {
+		return errors.Wrap(err, "Resource.SetStatementTimeout.ExecContext")
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package resourceful
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_processPolicy(t *testing.T) {
+	definition, err := NewDefinition(&productTable, DefinitionOption{
+		Views: []View{{Name: "expensive", Filters: []string{"count gt 100", "count lt 1000"}}},
+		Policy: Policy{
+			MaxLimit:         50,
+			MaxFilters:       2,
+			MaxSorts:         1,
+			MinSearchLength:  3,
+			MinPatternLength: 3,
+			StatementTimeout: time.Second,
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		param         Parameter
+		expectedError ValidationErrors
+	}{
+		{
+			name:          "error if limit is greater than the max limit",
+			param:         Parameter{Limit: 51, Page: 1},
+			expectedError: ValidationErrors{{FieldName: "limit", Errors: []string{"must be no greater than 50"}}},
+		},
+		{
+			name:          "error if filter conditions are more than the max filters",
+			param:         Parameter{Limit: 10, Page: 1, Filters: []string{"name eq a OR name eq b OR name eq c"}},
+			expectedError: ValidationErrors{{FieldName: "filters", Errors: []string{"must have no more than 2 filter conditions"}}},
+		},
+		{
+			name:          "error if view filters are more than the max filters",
+			param:         Parameter{Limit: 10, Page: 1, View: "expensive", Filters: []string{"name eq a"}},
+			expectedError: ValidationErrors{{FieldName: "filters", Errors: []string{"must have no more than 2 filter conditions"}}},
+		},
+		{
+			name:          "error if sorts are more than the max sorts",
+			param:         Parameter{Limit: 10, Page: 1, Sorts: []string{"name asc", "created_on asc"}},
+			expectedError: ValidationErrors{{FieldName: "sorts", Errors: []string{"must have no more than 1 sorts"}}},
+		},
+		{
+			name:  "error if search is shorter than the min search length",
+			param: Parameter{Limit: 10, Page: 1, Search: "ab name:c"},
+			expectedError: ValidationErrors{
+				{FieldName: "search", Errors: []string{"must be at least 3 characters"}},
+				{FieldName: "search", Errors: []string{"must be at least 3 characters at position 4"}},
+			},
+		},
+		{
+			name:  "error if leading wildcard pattern is shorter than the min pattern length",
+			param: Parameter{Limit: 10, Page: 1, Filters: []string{"name contains a", `name endswith "xy" OR name like **a*`}},
+			expectedError: ValidationErrors{
+				{FieldName: "filters", Errors: []string{"must have no more than 2 filter conditions"}},
+				{FieldName: "filters.1", Errors: []string{"pattern must be at least 3 characters at position 1"}},
+				{FieldName: "filters.2", Errors: []string{"pattern must be at least 3 characters at position 1"}},
+				{FieldName: "filters.2", Errors: []string{"pattern must be at least 3 characters at position 23"}},
+			},
+		},
+		{
+			name:  "no error if valid",
+			param: Parameter{Limit: 50, Page: 1, Search: "apple", View: "expensive", Sorts: []string{"name asc"}},
+		},
+		{
+			name:  "no error if pattern is anchored",
+			param: Parameter{Limit: 10, Page: 1, Filters: []string{"name startswith a OR name like a*"}},
+		},
+		{
+			name:  "no error if pattern is long enough",
+			param: Parameter{Limit: 10, Page: 1, Filters: []string{"name contains app", "name like *app*"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resource := NewResource[string, string](definition)
+			err := resource.SetParam(testCase.param)
+			if testCase.expectedError == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Equal(t, testCase.expectedError, err)
+		})
+	}
+}
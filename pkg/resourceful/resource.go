@@ -10,58 +10,124 @@ import (
 
 type Definition struct {
 	tableDefinition        *Table
+	searchStrategy         string
 	fieldsMap              map[string]*Field
 	searchFields           []*Field
 	defaultWhereStatements []string
 	defaultSortFields      []Field
 	defaultUsedTableMap    map[*Table]map[string]bool
+	collectionsMap         map[string]*Collection
+	views                  []View
+	viewsMap               map[string]View
+	policy                 Policy
 }
 
 // Create a new Definition
-func NewDefinition(tableDefinition *Table) (*Definition, error) {
+func NewDefinition(tableDefinition *Table, options ...DefinitionOption) (*Definition, error) {
 	fieldsMap, searchFields, defaultWhereStatements, defaultSortFields, defaultUsedTableMap, err := tableDefinition.init()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Definition{
+	searchStrategy := LIKE_SEARCH
+	if len(options) >= 1 && options[0].SearchStrategy != "" {
+		searchStrategy = options[0].SearchStrategy
+	}
+	err = validateSearchStrategy(searchStrategy, searchFields)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionsMap, err := tableDefinition.initCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	definition := &Definition{
 		tableDefinition:        tableDefinition,
+		searchStrategy:         searchStrategy,
 		fieldsMap:              fieldsMap,
 		searchFields:           searchFields,
 		defaultWhereStatements: defaultWhereStatements,
 		defaultSortFields:      defaultSortFields,
 		defaultUsedTableMap:    defaultUsedTableMap,
-	}, nil
+		collectionsMap:         collectionsMap,
+	}
+
+	var views []View
+	if len(options) >= 1 {
+		views = options[0].Views
+		definition.policy = options[0].Policy
+	}
+	err = definition.initViews(views)
+	if err != nil {
+		return nil, err
+	}
+
+	return definition, nil
 }
 
 type Resource[IDType, Model any] struct {
 	// Refreshed State (on SetParam)
-	Parameter        *Parameter
-	isProcessed      bool
-	selectStatements []string
-	whereStatements  []string
-	sortStatements   []string
-	queryArgs        []any
-	usedTablesMap    map[*Table]map[string]bool
-	result           *Result[IDType, Model]
+	Parameter           *Parameter
+	isProcessed         bool
+	selectedFields      []*Field
+	selectFields        []*Field
+	selectStatements    []string
+	whereStatements     []string
+	filterFieldsMap     map[int][]*Field
+	rankStatement       string
+	sortStatements      []string
+	sortOrders          []sortOrder
+	groupByFields       []*Field
+	aggregations        []Aggregation
+	facetFields         []*Field
+	facetKeys           map[*Field]string
+	facets              map[string][]FacetValue
+	includedCollections []*Collection
+	cursorValues        []any
+	nextCursor          string
+	totalCount          int
+	isCounted           bool
+	queryArgs           []any
+	usedTablesMap       map[*Table]map[string]bool
+	result              *Result[IDType, Model]
+	cacheStoreKey       string
 
 	// Persistance State
 	tableDefinition        *Table
+	searchStrategy         string
 	fieldsMap              map[string]*Field
 	searchFields           []*Field
 	defaultWhereStatements []string
 	defaultSortFields      []Field
 	defaultUsedTableMap    map[*Table]map[string]bool
+	collectionsMap         map[string]*Collection
+	viewsMap               map[string]View
+	policy                 Policy
+	scopes                 []scope
+	softDeleteMode         string
 	isAPIResource          bool
 }
 
+const (
+	PAGE_PAGINATION   = "page"
+	CURSOR_PAGINATION = "cursor"
+)
+
 type Parameter struct {
+	Pagination   string
 	Limit        int
 	Page         int
+	Cursor       string
 	Search       string
 	LocalFilters []string
 	Filters      []string
 	Sorts        []string
+	Fields       []string
+	Facets       []string
+	Includes     []string
+	View         string
 }
 
 type Result[IDType, Model any] struct {
@@ -77,9 +143,11 @@ func NewResource[IDType, Model any](definition *Definition) *Resource[IDType, Mo
 		defaultWhereStatements []string
 		defaultSortFields      []Field
 		defaultUsedTableMap    map[*Table]map[string]bool
+		viewsMap               map[string]View
 	)
 	fieldsMap = make(map[string]*Field)
 	defaultUsedTableMap = make(map[*Table]map[string]bool)
+	viewsMap = make(map[string]View)
 
 	// Copy definition
 	for fieldKey, field := range definition.fieldsMap {
@@ -96,14 +164,21 @@ func NewResource[IDType, Model any](definition *Definition) *Resource[IDType, Mo
 
 		defaultUsedTableMap[table] = newUsageMap
 	}
+	for name, view := range definition.viewsMap {
+		viewsMap[name] = view
+	}
 
 	return &Resource[IDType, Model]{
 		tableDefinition:        definition.tableDefinition,
+		searchStrategy:         definition.searchStrategy,
 		fieldsMap:              fieldsMap,
 		searchFields:           searchFields,
 		defaultWhereStatements: defaultWhereStatements,
 		defaultSortFields:      defaultSortFields,
 		defaultUsedTableMap:    defaultUsedTableMap,
+		collectionsMap:         definition.collectionsMap,
+		viewsMap:               viewsMap,
+		policy:                 definition.policy,
 	}
 }
 func NewAPIResource[IDType, Model comparable](param Parameter) *Resource[IDType, Model] {
@@ -142,19 +217,69 @@ func (r *Resource[IDType, Model]) SetParam(param Parameter) error {
 		validationError = append(validationError, validationErr...)
 	}
 
+	view, err := r.processView(param.View)
+	if err != nil {
+		validationErr, _ := err.(ValidationErrors)
+		validationError = append(validationError, validationErr...)
+	}
+
+	err = r.processPolicy(param, view)
+	if err != nil {
+		validationErr, _ := err.(ValidationErrors)
+		validationError = append(validationError, validationErr...)
+	}
+
 	err = r.processFilters(param.Filters)
 	if err != nil {
 		validationErr, _ := err.(ValidationErrors)
 		validationError = append(validationError, validationErr...)
 	}
 
+	// The view filters are merged with the filters param
+	if view != nil {
+		err = r.processFilterParams("view.filters", view.Filters)
+		if err != nil {
+			validationErr, _ := err.(ValidationErrors)
+			validationError = append(validationError, validationErr...)
+		}
+	}
+
 	err = r.processLocalFilters(param.LocalFilters)
 	if err != nil {
 		validationErr, _ := err.(ValidationErrors)
 		validationError = append(validationError, validationErr...)
 	}
 
-	err = r.processSorts(param.Sorts)
+	// The view sorts are used only when there is no sorts param
+	if view != nil && len(param.Sorts) == 0 && len(view.Sorts) > 0 {
+		err = r.processSortParams("view.sorts", view.Sorts)
+	} else {
+		err = r.processSorts(param.Sorts)
+	}
+	if err != nil {
+		validationErr, _ := err.(ValidationErrors)
+		validationError = append(validationError, validationErr...)
+	}
+
+	err = r.processPagination(param)
+	if err != nil {
+		validationErr, _ := err.(ValidationErrors)
+		validationError = append(validationError, validationErr...)
+	}
+
+	err = r.processFields(param.Fields)
+	if err != nil {
+		validationErr, _ := err.(ValidationErrors)
+		validationError = append(validationError, validationErr...)
+	}
+
+	err = r.processFacets(param.Facets)
+	if err != nil {
+		validationErr, _ := err.(ValidationErrors)
+		validationError = append(validationError, validationErr...)
+	}
+
+	err = r.processIncludes(param.Includes)
 	if err != nil {
 		validationErr, _ := err.(ValidationErrors)
 		validationError = append(validationError, validationErr...)
@@ -164,6 +289,8 @@ func (r *Resource[IDType, Model]) SetParam(param Parameter) error {
 		return validationError
 	}
 
+	r.processScopes()
+
 	r.isProcessed = true
 
 	return nil
@@ -173,7 +300,14 @@ func (r *Resource[IDType, Model]) GetParamUri() string {
 	stringBuilder := new(strings.Builder)
 
 	stringBuilder.WriteString(fmt.Sprintf("limit=%d&", r.Parameter.Limit))
-	stringBuilder.WriteString(fmt.Sprintf("page=%d&", r.Parameter.Page))
+	if r.IsCursorPagination() {
+		stringBuilder.WriteString(fmt.Sprintf("pagination=%s&", CURSOR_PAGINATION))
+		if r.Parameter.Cursor != "" {
+			stringBuilder.WriteString(fmt.Sprintf("cursor=%s&", url.QueryEscape(r.Parameter.Cursor)))
+		}
+	} else {
+		stringBuilder.WriteString(fmt.Sprintf("page=%d&", r.Parameter.Page))
+	}
 	if r.Parameter.Search != "" {
 		stringBuilder.WriteString(fmt.Sprintf("search=%s&", url.QueryEscape(r.Parameter.Search)))
 	}
@@ -187,16 +321,29 @@ func (r *Resource[IDType, Model]) Select(fields []*Field) {
 	if r.selectStatements != nil {
 		r.selectStatements = nil
 	}
+	r.selectFields = nil
 	r.unuseTableFor(SELECT)
 
 	for _, field := range fields {
 		if field != nil {
-			r.selectStatements = append(r.selectStatements, field.statement)
+			selectStatement := field.statement
+			// The computed field is selected as its name so it can be scanned like a column
+			if field.Expression != "" {
+				selectStatement = fmt.Sprintf(`%s AS "%s"`, field.statement, field.Name)
+			}
+
+			r.selectStatements = append(r.selectStatements, selectStatement)
+			r.selectFields = append(r.selectFields, field)
 			r.useFieldFor(field, SELECT)
 		}
 	}
 }
 
+// Return the Selectable fields requested by the Parameter fields, empty if no fields requested
+func (r *Resource[IDType, Model]) SelectedFields() []*Field {
+	return r.selectedFields
+}
+
 // Get the Resource query & args
 func (r *Resource[IDType, Model]) QueryAndArgs() (string, []any, error) {
 	if !r.isProcessed {
@@ -212,15 +359,16 @@ func (r *Resource[IDType, Model]) QueryAndArgs() (string, []any, error) {
 	queryStatement += fmt.Sprintf("SELECT %s FROM %s", strings.Join(r.selectStatements, ", "), r.tableDefinition.statement)
 
 	// Join Statements
-	joinStatements := r.getJoinStatements([]string{SELECT, SEARCH, FILTER, SORT, MANDATORY})
+	joinStatements := r.getJoinStatements([]string{SELECT, SEARCH, FILTER, OPTIONAL_FILTER, SORT, MANDATORY})
 	if len(joinStatements) > 0 {
 		queryStatement += "\n" + strings.Join(joinStatements, "\n")
 	}
 
 	// Where Statement
-	r.whereStatements = append(r.whereStatements, r.defaultWhereStatements...)
-	if len(r.whereStatements) > 0 {
-		queryStatement += "\nWHERE " + strings.Join(r.whereStatements, "\nAND ")
+	whereStatements := append([]string{}, r.whereStatements...)
+	whereStatements = append(whereStatements, r.defaultWhereStatements...)
+	if len(whereStatements) > 0 {
+		queryStatement += "\nWHERE " + strings.Join(whereStatements, "\nAND ")
 	}
 
 	// Sort Statement
@@ -261,27 +409,86 @@ func (r *Resource[IDType, Model]) PopulateQueryArgs(idField *Field, ids []IDType
 	}
 	queryStatement += fmt.Sprintf("\nWHERE %s IN (%s)", idField.statement, strings.Join(whereInStatements, ", "))
 
-	// Sort Statement
-	if len(r.sortStatements) > 0 {
-		queryStatement += "\nORDER BY " + strings.Join(r.sortStatements, ", ")
+	// Sort Statement, the search rank args are rebound after the ids. The id is the last sort as on the page query
+	sortStatements, queryArgs := rebindStatements(r.sortStatements, r.queryArgs, queryArgs)
+	sortStatements = append(sortStatements, fmt.Sprintf("%s %s", idField.statement, sortOperators["asc"]))
+	queryStatement += "\nORDER BY " + strings.Join(sortStatements, ", ")
+
+	return queryStatement, queryArgs, nil
+}
+
+// Get the Resource page query & args with LIMIT & OFFSET, the result rows are the idField followed by the total count.
+// Use the ScanOffsetRows method to read the result rows
+func (r *Resource[IDType, Model]) OffsetQueryAndArgs(idField *Field) (string, []any, error) {
+	if !r.isProcessed {
+		return "", nil, createError("SetParam method must be called")
+	}
+	if r.IsCursorPagination() {
+		return "", nil, createError("page pagination must be used")
+	}
+	if idField == nil {
+		return "", nil, createError("id field can't be empty")
+	}
+	if r.Parameter.Limit < 1 || r.Parameter.Page < 1 {
+		return "", nil, ErrPagination
+	}
+
+	var queryStatement string
+
+	// Select Statement
+	queryStatement += fmt.Sprintf("SELECT %s, COUNT(*) OVER() FROM %s", idField.statement, r.tableDefinition.statement)
+
+	// Join Statements
+	joinStatements := r.getJoinStatements([]string{SEARCH, FILTER, OPTIONAL_FILTER, SORT, MANDATORY})
+	if len(joinStatements) > 0 {
+		queryStatement += "\n" + strings.Join(joinStatements, "\n")
 	}
 
+	// Where Statement
+	queryArgs := append([]any{}, r.queryArgs...)
+	whereStatements := append([]string{}, r.whereStatements...)
+	whereStatements = append(whereStatements, r.defaultWhereStatements...)
+	if len(whereStatements) > 0 {
+		queryStatement += "\nWHERE " + strings.Join(whereStatements, "\nAND ")
+	}
+
+	// Sort Statement, the id is the last sort so the tied rows keep the same order between the pages
+	sortStatements := append([]string{}, r.sortStatements...)
+	sortStatements = append(sortStatements, fmt.Sprintf("%s %s", idField.statement, sortOperators["asc"]))
+	queryStatement += "\nORDER BY " + strings.Join(sortStatements, ", ")
+
+	// Limit & Offset Statement
+	queryArgs = append(queryArgs, r.Parameter.Limit, r.Parameter.Limit*(r.Parameter.Page-1))
+	queryStatement += fmt.Sprintf("\nLIMIT $%d OFFSET $%d", len(queryArgs)-1, len(queryArgs))
+
 	return queryStatement, queryArgs, nil
 }
 
-// Helper function to return the new slice from the results based on Resource limit & page parameter
-// !!! FIXED SOON
-func (r *Resource[IDType, Model]) GetPaginatedResults(results []IDType) ([]IDType, error) {
-	endOffset := (r.Parameter.Limit * r.Parameter.Page)
-	if endOffset > len(results) {
-		endOffset = len(results)
+// Read the OffsetQueryAndArgs result rows, return the ids of the current page and set the total count.
+// ErrPagination is returned when the page is out of range
+func (r *Resource[IDType, Model]) ScanOffsetRows(rows Rows) ([]IDType, error) {
+	var ids []IDType
+
+	for rows.Next() {
+		var id IDType
+		err := rows.Scan(&id, &r.totalCount)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
 	}
-	startOffset := (r.Parameter.Limit * r.Parameter.Page) - r.Parameter.Limit
-	if startOffset > (endOffset - 1) {
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 && r.Parameter.Page > 1 {
 		return nil, ErrPagination
 	}
+	r.isCounted = true
 
-	return results[startOffset:endOffset], nil
+	return ids, nil
 }
 
 // Set the result to the Resource so the ResourfulResponse function can be used later
@@ -296,6 +503,9 @@ func (r *Resource[IDType, Model]) Metadata() *Metadata {
 	metadata.Page = r.Parameter.Page
 	if r.result != nil {
 		metadata.TotalCount = len(r.result.Ids)
+		if r.isCounted {
+			metadata.TotalCount = r.totalCount
+		}
 		metadata.Count = len(r.result.PaginatedResult)
 		metadata.TotalPage = int(math.Ceil(float64(metadata.TotalCount) / float64(r.Parameter.Limit)))
 	}
@@ -303,11 +513,29 @@ func (r *Resource[IDType, Model]) Metadata() *Metadata {
 	return &metadata
 }
 
+// Will return the Resource cursor metadata from the result
+func (r *Resource[IDType, Model]) CursorMetadata() *CursorMetadata {
+	var metadata CursorMetadata
+
+	metadata.NextCursor = r.nextCursor
+	metadata.HasNext = r.nextCursor != ""
+	if r.result != nil {
+		metadata.Count = len(r.result.PaginatedResult)
+	}
+
+	return &metadata
+}
+
 // Build the resrouceful response
 func (r *Resource[IDType, Model]) Response() *Response[IDType, Model] {
 	var response Response[IDType, Model]
 
-	response.Metadata = r.Metadata()
+	if r.IsCursorPagination() {
+		response.Metadata = r.CursorMetadata()
+	} else {
+		response.Metadata = r.Metadata()
+	}
+	response.Facets = r.facets
 	response.Data.Ids = make([]IDType, 0)
 	response.Data.PaginatedResult = make([]Model, 0)
 
@@ -362,152 +590,298 @@ func (r *Resource[IDType, Model]) processSearch(searchParam string) error {
 		return validationErr
 	}
 
-	if searchParam != "" {
-
-		var searchStatements []string
-		for _, searchField := range r.searchFields {
-			if searchField.Type == NUMERIC {
-				_, err := strconv.ParseFloat(searchParam, 64)
+	if searchParam == "" {
+		return nil
+	}
 
-				if err == nil {
-					r.useFieldFor(searchField, SEARCH)
-					r.queryArgs = append(r.queryArgs, searchParam+"%")
-					searchStatements = append(searchStatements, fmt.Sprintf("%s::text like $%d", searchField.statement, len(r.queryArgs)))
-				}
-			} else {
-				r.useFieldFor(searchField, SEARCH)
-				r.queryArgs = append(r.queryArgs, "%"+strings.ToLower(searchParam)+"%")
-				searchStatements = append(searchStatements, fmt.Sprintf("lower(%s) like $%d", searchField.statement, len(r.queryArgs)))
-			}
+	// Validate the targeted search terms
+	freeText, searchTerms := parseSearch(searchParam, r.isSearchKey)
+	searchTermFields := make([]*Field, len(searchTerms))
+	for key, searchTerm := range searchTerms {
+		searchField, _ := r.searchField(searchTerm.key)
+		if searchTerm.value == "" {
+			validationErr.appendFieldError("search", fmt.Sprintf("missing value at position %d", searchTerm.position))
+			continue
+		}
+		if _, err := strconv.ParseFloat(searchTerm.value, 64); searchField.Type == NUMERIC && err != nil {
+			validationErr.appendFieldError("search", fmt.Sprintf("value must be a valid %s format at position %d", NUMERIC, searchTerm.position))
+			continue
+		}
+		if _, err := strconv.ParseInt(searchTerm.value, 10, 64); searchField.Type == INTEGER && err != nil {
+			validationErr.appendFieldError("search", fmt.Sprintf("value must be a valid %s format at position %d", INTEGER, searchTerm.position))
+			continue
 		}
 
-		searchStatement := fmt.Sprintf("(%s)", strings.Join(searchStatements, " OR "))
+		searchTermFields[key] = searchField
+	}
+
+	if len(validationErr) > 0 {
+		return validationErr
+	}
+
+	// The free text is matched against all the search fields & the search term only against its field
+	var rankStatements []string
+	if freeText != "" {
+		searchStatement, rankStatement := r.searchStatement(r.searchFields, freeText)
+		r.whereStatements = append(r.whereStatements, searchStatement)
+		if rankStatement != "" {
+			rankStatements = append(rankStatements, rankStatement)
+		}
+	}
+	for key, searchTerm := range searchTerms {
+		searchStatement, rankStatement := r.searchStatement([]*Field{searchTermFields[key]}, searchTerm.value)
 		r.whereStatements = append(r.whereStatements, searchStatement)
+		if rankStatement != "" {
+			rankStatements = append(rankStatements, rankStatement)
+		}
 	}
+	r.rankStatement = strings.Join(rankStatements, " + ")
 
 	return nil
 }
 
 func (r *Resource[IDType, Model]) processFilters(filterParams []string) error {
+	return r.processFilterParams("filters", filterParams)
+}
+
+// Validate & process the filters, the validation error field is the paramKey followed by the filter position
+func (r *Resource[IDType, Model]) processFilterParams(paramKey string, filterParams []string) error {
 	var validationErrors ValidationErrors
 
 	for key, filterParam := range filterParams {
-		filterParam = strings.TrimSpace(filterParam)
-		filterParamSplit := strings.Split(filterParam, " ")
-
-		// Validate Format
-		if len(filterParamSplit) < 3 {
-			validationErrors.appendFieldError(fmt.Sprintf("filters.%d", (key+1)), "invalid format")
+		filterNode, err := parseFilterExpression(filterParam)
+		if err != nil {
+			validationErrors.appendFieldError(fmt.Sprintf("%s.%d", paramKey, (key+1)), err.Error())
 			continue
 		}
 
-		// Validate Field
-		filterKey := filterParamSplit[0]
-		var (
-			filterField *Field
-			ok          bool
-		)
-		if filterField, ok = r.fieldsMap[filterKey]; !ok || !filterField.Filterable {
-			validationErrors.appendFieldError(fmt.Sprintf("filters.%d", (key+1)), "invalid field")
+		// Validate & Process Filter
+		whereStatement, err := r.filterExpressionStatement(filterNode, FILTER)
+		if err != nil {
+			validationErrors.appendFieldError(fmt.Sprintf("%s.%d", paramKey, (key+1)), err.Error())
 			continue
 		}
 
-		// Validate Operator
-		filterOperator := filterParamSplit[1]
-		if !validFilterOperator(filterOperator) {
-			validationErrors.appendFieldError(fmt.Sprintf("filters.%d", (key+1)), "invalid operator")
+		if len(validationErrors) != 0 {
 			continue
 		}
 
-		// Validate Value
-		rawFilterValue := strings.Join(filterParamSplit[2:], " ")
-		filterValues, ok := sanitizeFilterValueByType(rawFilterValue, filterField.Type)
+		r.appendFilterStatement(whereStatement, filterNode)
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	return nil
+}
+
+// Append the client filter where statement & keep the filter fields, so the facet of the field can exclude it
+func (r *Resource[IDType, Model]) appendFilterStatement(whereStatement string, node *filterNode) {
+	if r.filterFieldsMap == nil {
+		r.filterFieldsMap = make(map[int][]*Field)
+	}
+
+	for _, key := range node.keys() {
+		field, ok := r.fieldsMap[key]
 		if !ok {
-			validationErrors.appendFieldError(fmt.Sprintf("filters.%d", (key+1)), fmt.Sprintf("value must be a valid %s format", filterField.Type))
-			continue
+			field, _ = r.jsonbPathField(key)
 		}
+		r.filterFieldsMap[len(r.whereStatements)] = append(r.filterFieldsMap[len(r.whereStatements)], field)
+	}
+	r.whereStatements = append(r.whereStatements, whereStatement)
+}
 
-		// Process Filter
-		if len(validationErrors) != 0 {
-			continue
+// Build the where statement of the filter AST, the relation tables used inside
+// the OR & NOT expression are marked as OPTIONAL_FILTER so they are LEFT JOINed
+func (r *Resource[IDType, Model]) filterExpressionStatement(node *filterNode, usage string) (string, error) {
+	if node.expression == "" {
+		return r.filterConditionStatement(node, usage)
+	}
+
+	if node.expression != AND_EXPRESSION {
+		usage = OPTIONAL_FILTER
+	}
+
+	var statements []string
+	for _, child := range node.children {
+		statement, err := r.filterExpressionStatement(child, usage)
+		if err != nil {
+			return "", err
 		}
 
-		if filterOperator == "in" {
-			inStatement := make([]string, 0, len(filterValues))
-			for _, filterValue := range filterValues {
-				r.queryArgs = append(r.queryArgs, filterValue)
-				inStatement = append(inStatement, fmt.Sprintf("$%d", len(r.queryArgs)))
+		statements = append(statements, statement)
+	}
+
+	if node.expression == NOT_EXPRESSION {
+		return fmt.Sprintf("NOT (%s)", statements[0]), nil
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(statements, fmt.Sprintf(" %s ", node.expression))), nil
+}
+
+func (r *Resource[IDType, Model]) filterConditionStatement(node *filterNode, usage string) (string, error) {
+	// Validate Field
+	var (
+		filterField *Field
+		ok          bool
+	)
+	filterField, ok = r.fieldsMap[node.key]
+	if !ok {
+		filterField, ok = r.jsonbPathField(node.key)
+		if ok && !jsonbPathFilterOperators[node.operator] {
+			return "", newFilterExpressionError(fmt.Sprintf("operator not available on %s path", JSONB), node.position)
+		}
+	}
+	if !ok || (!filterField.Filterable && !filterField.PatternFilterable) {
+		return "", newFilterExpressionError("invalid field", node.position)
+	}
+	if filterField.Type == JSONB {
+		return "", newFilterExpressionError(fmt.Sprintf("%s field must be filtered by the path, e.g. %s.key", JSONB, node.key), node.position)
+	}
+
+	// Only pattern operators for the PatternFilterable only field
+	if _, isPatternOperator := patternFilterOperators[node.operator]; !isPatternOperator && !filterField.Filterable {
+		return "", newFilterExpressionError("invalid operator", node.position)
+	}
+
+	return r.conditionStatement(filterField, node, usage)
+}
+
+// Validate the condition operator & value by the field, then build the where statement
+func (r *Resource[IDType, Model]) conditionStatement(filterField *Field, node *filterNode, usage string) (string, error) {
+	// Validate Operator
+	_, isPatternOperator := patternFilterOperators[node.operator]
+	if !validFilterOperator(node.operator) {
+		return "", newFilterExpressionError("invalid operator", node.position)
+	}
+	if isPatternOperator && !filterField.PatternFilterable {
+		return "", newFilterExpressionError("operator only available on pattern filterable field", node.position)
+	}
+
+	if isUnaryFilterOperator(node.operator) {
+		r.useFieldFor(filterField, usage)
+		return fmt.Sprintf("%s %s", filterField.statement, unaryFilterOperators[node.operator]), nil
+	}
+
+	// Validate Value
+	filterValues, ok := sanitizeFilterValueByType(node.value, filterField.Type)
+	if !ok {
+		return "", newFilterExpressionError(filterValueErrorMessage(filterField), node.position)
+	}
+	if filterField.Type == ENUM {
+		for _, filterValue := range filterValues {
+			if !validEnumValue(filterField, filterValue) {
+				return "", newFilterExpressionError(filterValueErrorMessage(filterField), node.position)
 			}
+		}
+	}
 
-			whereStatement := fmt.Sprintf("%s in (%s)", filterField.statement, strings.Join(inStatement, ","))
-			r.whereStatements = append(r.whereStatements, whereStatement)
-			r.useFieldFor(filterField, FILTER)
-			continue
+	rangeOperator, isRangeOperator := rangeFilterOperators[node.operator]
+	if isRangeOperator {
+		if !isRangeType(filterField.Type) {
+			return "", newFilterExpressionError(fmt.Sprintf("operator only available on %s, %s or %s field", NUMERIC, INTEGER, DATE), node.position)
 		}
+		if len(filterValues) != 2 {
+			return "", newFilterExpressionError("value must be two values", node.position)
+		}
+	}
 
-		r.queryArgs = append(r.queryArgs, filterValues[0])
-		whereStatement := fmt.Sprintf("%s %s $%d", filterField.statement, filterOperators[filterOperator], len(r.queryArgs))
-		r.whereStatements = append(r.whereStatements, whereStatement)
-		r.useFieldFor(filterField, FILTER)
+	// Process Filter
+	r.useFieldFor(filterField, usage)
+
+	if multiValueOperator, ok := multiValueFilterOperators[node.operator]; ok {
+		inStatement := make([]string, 0, len(filterValues))
+		for _, filterValue := range filterValues {
+			r.queryArgs = append(r.queryArgs, filterValue)
+			inStatement = append(inStatement, fmt.Sprintf("$%d", len(r.queryArgs)))
+		}
 
+		return fmt.Sprintf("%s %s (%s)", filterField.statement, multiValueOperator, strings.Join(inStatement, ",")), nil
 	}
 
-	if len(validationErrors) > 0 {
-		return validationErrors
+	if isRangeOperator {
+		r.queryArgs = append(r.queryArgs, filterValues[0], filterValues[1])
+		return fmt.Sprintf("%s %s $%d AND $%d", filterField.statement, rangeOperator, len(r.queryArgs)-1, len(r.queryArgs)), nil
 	}
 
-	return nil
+	if isPatternOperator {
+		r.queryArgs = append(r.queryArgs, patternFilterValue(node.operator, filterValues[0]))
+		return fmt.Sprintf("%s %s $%d", filterField.statement, patternFilterOperators[node.operator], len(r.queryArgs)), nil
+	}
+
+	r.queryArgs = append(r.queryArgs, filterValues[0])
+	return fmt.Sprintf("%s %s $%d", filterField.statement, filterOperators[node.operator], len(r.queryArgs)), nil
+}
+
+// Resolve the "field.path" key of the JSONB field, e.g. attributes.color. The path field is the STRING copy
+// of the JSONB field with the text extraction statement
+func (r *Resource[IDType, Model]) jsonbPathField(key string) (*Field, bool) {
+	for dot := 0; dot < len(key); dot++ {
+		if key[dot] != '.' {
+			continue
+		}
+
+		field, ok := r.fieldsMap[key[:dot]]
+		if !ok || field.Type != JSONB {
+			continue
+		}
+
+		path := strings.Split(key[dot+1:], ".")
+		for _, pathSegment := range path {
+			if !jsonbPathSegmentRegexp.MatchString(pathSegment) {
+				return nil, false
+			}
+		}
+
+		pathField := *field
+		pathField.Type = STRING
+		pathField.statement = fmt.Sprintf("(%s #>> '{%s}')", field.statement, strings.Join(path, ","))
+
+		return &pathField, true
+	}
+
+	return nil, false
 }
 
 func (r *Resource[IDType, Model]) processLocalFilters(localFilterParams []string) error {
 	var validationErrors ValidationErrors
 
 	for key, localFilterParam := range localFilterParams {
-		localFilterParam = strings.TrimSpace(localFilterParam)
-		localFilterSplit := strings.Split(localFilterParam, " ")
-
-		// Validate Format
-		if len(localFilterSplit) < 3 {
+		// Validate Format, local filter is a single condition
+		filterNode, err := parseFilterExpression(localFilterParam)
+		if err != nil {
+			validationErrors.appendFieldError(fmt.Sprintf("localFilters.%d", (key+1)), err.Error())
+			continue
+		}
+		if filterNode.expression != "" {
 			validationErrors.appendFieldError(fmt.Sprintf("localFilters.%d", (key+1)), "invalid format")
 			continue
 		}
 
 		// Validate Field
-		filterKey := localFilterSplit[0]
 		var (
 			filterField *Field
 			ok          bool
 		)
-		if filterField, ok = r.fieldsMap[filterKey]; !ok || (!filterField.LocalFilterable && !filterField.Filterable) {
+		if filterField, ok = r.fieldsMap[filterNode.key]; !ok || (!filterField.LocalFilterable && !filterField.Filterable) {
 			validationErrors.appendFieldError(fmt.Sprintf("localFilters.%d", (key+1)), "invalid field")
 			continue
 		}
 
-		// Validate Operator
-		filterOperator := localFilterSplit[1]
-		if _, ok := filterOperators[filterOperator]; !ok {
-			validationErrors.appendFieldError(fmt.Sprintf("localFilters.%d", (key+1)), "invalid operator")
+		// Validate Operator & Value, then Process Filter
+		whereStatement, err := r.conditionStatement(filterField, filterNode, FILTER)
+		if err != nil {
+			validationErrors.appendFieldError(fmt.Sprintf("localFilters.%d", (key+1)), err.Error())
 			continue
 		}
 
-		// Validate Value
-		filterValue := strings.Join(localFilterSplit[2:], " ")
-		//TODO:  implement IN in Local Filter
-		if _, ok := sanitizeFilterValueByType(filterValue, filterField.Type); !ok {
-			validationErrors.appendFieldError(fmt.Sprintf("localFilters.%d", (key+1)), fmt.Sprintf("value must be a valid %s", filterField.Type))
-			continue
-		}
-
-		// Process Filter
 		if len(validationErrors) != 0 {
 			continue
 		}
 
-		r.queryArgs = append(r.queryArgs, filterValue)
-		whereStatement := fmt.Sprintf("%s %s $%d", filterField.statement, filterOperators[filterOperator], len(r.queryArgs))
+		// The server side local filter (e.g. the tenant filter) is kept on the facet of the same field
 		r.whereStatements = append(r.whereStatements, whereStatement)
-
-		r.useFieldFor(filterField, FILTER)
 	}
 
 	if len(validationErrors) > 0 {
@@ -518,11 +892,17 @@ func (r *Resource[IDType, Model]) processLocalFilters(localFilterParams []string
 }
 
 func (r *Resource[IDType, Model]) processSorts(sortParams []string) error {
+	return r.processSortParams("sorts", sortParams)
+}
+
+// Validate & process the sorts followed by the default sorts, the validation error field is the paramKey followed by the sort position
+func (r *Resource[IDType, Model]) processSortParams(paramKey string, sortParams []string) error {
 	var (
 		validationErrors  ValidationErrors
 		usedSortKey       map[string]bool
 		defaultSortFields []Field
 		sortStatements    []string
+		sortOrders        []sortOrder
 	)
 	usedSortKey = make(map[string]bool)
 	defaultSortFields = make([]Field, len(r.defaultSortFields))
@@ -534,7 +914,7 @@ func (r *Resource[IDType, Model]) processSorts(sortParams []string) error {
 
 		// Validate Format
 		if len(sortParamSplit) != 2 {
-			validationErrors.appendFieldError(fmt.Sprintf("sorts.%d", (key+1)), "invalid sorts format")
+			validationErrors.appendFieldError(fmt.Sprintf("%s.%d", paramKey, (key+1)), "invalid sorts format")
 			continue
 		}
 
@@ -545,30 +925,31 @@ func (r *Resource[IDType, Model]) processSorts(sortParams []string) error {
 			ok        bool
 		)
 		if sortField, ok = r.fieldsMap[sortKey]; !ok || !r.fieldsMap[sortKey].Sortable {
-			validationErrors.appendFieldError(fmt.Sprintf("sorts.%d", (key+1)), "invalid sorts field")
+			validationErrors.appendFieldError(fmt.Sprintf("%s.%d", paramKey, (key+1)), "invalid sorts field")
 			continue
 		}
 		if _, ok := usedSortKey[sortKey]; ok {
-			validationErrors.appendFieldError(fmt.Sprintf("sorts.%d", (key+1)), fmt.Sprintf(`duplicate with "%s" field`, sortKey))
+			validationErrors.appendFieldError(fmt.Sprintf("%s.%d", paramKey, (key+1)), fmt.Sprintf(`duplicate with "%s" field`, sortKey))
 			continue
 		}
 
 		// Validate Operator
 		sortOpeartor := sortParamSplit[1]
 		if _, ok := sortOperators[sortOpeartor]; !ok {
-			validationErrors.appendFieldError(fmt.Sprintf("sorts.%d", (key+1)), "invalid sorts operator")
+			validationErrors.appendFieldError(fmt.Sprintf("%s.%d", paramKey, (key+1)), "invalid sorts operator")
 			continue
 		}
 
 		if len(validationErrors) == 0 {
 			// Check if sort key override the defaultSortFields
 			for key, defaultSortField := range defaultSortFields {
-				if defaultSortField == *sortField {
+				if defaultSortField.statement == sortField.statement {
 					defaultSortFields = append(defaultSortFields[:key], defaultSortFields[key+1:]...)
 				}
 			}
 			sortStatement := fmt.Sprintf("%s %s", sortField.statement, sortOperators[sortOpeartor])
 			sortStatements = append(sortStatements, sortStatement)
+			sortOrders = append(sortOrders, sortOrder{field: sortField, operator: sortOpeartor})
 
 			usedSortKey[sortKey] = true
 			r.useFieldFor(sortField, SORT)
@@ -579,14 +960,62 @@ func (r *Resource[IDType, Model]) processSorts(sortParams []string) error {
 		return validationErrors
 	}
 
+	// Order by the search rank when there is no sorts param
+	if len(sortParams) == 0 && r.rankStatement != "" {
+		rankField := &Field{Name: "rank", statement: r.rankStatement, table: r.tableDefinition}
+		sortStatements = append(sortStatements, fmt.Sprintf("%s %s", rankField.statement, sortOperators["desc"]))
+		sortOrders = append(sortOrders, sortOrder{field: rankField, operator: "desc"})
+	}
+
 	for _, defaultSortField := range defaultSortFields {
+		defaultSortField := defaultSortField
 		sortStatement := fmt.Sprintf("%s %s", defaultSortField.statement, sortOperators[defaultSortField.Sort])
 		sortStatements = append(sortStatements, sortStatement)
+		sortOrders = append(sortOrders, sortOrder{field: &defaultSortField, operator: defaultSortField.Sort})
 
 		r.useFieldFor(&defaultSortField, SORT)
 	}
 
 	r.sortStatements = append(r.sortStatements, sortStatements...)
+	r.sortOrders = append(r.sortOrders, sortOrders...)
+
+	return nil
+}
+
+func (r *Resource[IDType, Model]) processFields(fieldParams []string) error {
+	var (
+		validationErrors ValidationErrors
+		usedFieldKey     map[string]bool
+		selectedFields   []*Field
+	)
+	usedFieldKey = make(map[string]bool)
+
+	for key, fieldParam := range fieldParams {
+		fieldKey := strings.TrimSpace(fieldParam)
+
+		// Validate Field
+		var (
+			field *Field
+			ok    bool
+		)
+		if field, ok = r.fieldsMap[fieldKey]; !ok || !field.Selectable {
+			validationErrors.appendFieldError(fmt.Sprintf("fields.%d", (key+1)), "invalid field")
+			continue
+		}
+		if _, ok := usedFieldKey[fieldKey]; ok {
+			validationErrors.appendFieldError(fmt.Sprintf("fields.%d", (key+1)), fmt.Sprintf(`duplicate with "%s" field`, fieldKey))
+			continue
+		}
+
+		usedFieldKey[fieldKey] = true
+		selectedFields = append(selectedFields, field)
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	r.selectedFields = selectedFields
 
 	return nil
 }
@@ -614,11 +1043,27 @@ func (r *Resource[IDType, Model]) unuseTableFor(usage string) {
 func (r *Resource[IDType, Model]) cleanState() {
 	r.Parameter = nil
 	r.isProcessed = false
+	r.selectedFields = nil
+	r.selectFields = nil
 	r.selectStatements = nil
 	r.whereStatements = nil
+	r.filterFieldsMap = nil
+	r.rankStatement = ""
 	r.sortStatements = nil
+	r.sortOrders = nil
+	r.groupByFields = nil
+	r.aggregations = nil
+	r.facetFields = nil
+	r.facetKeys = nil
+	r.facets = nil
+	r.includedCollections = nil
+	r.cursorValues = nil
+	r.nextCursor = ""
+	r.totalCount = 0
+	r.isCounted = false
 	r.usedTablesMap = nil
 	r.queryArgs = nil
 	r.result = nil
+	r.cacheStoreKey = ""
 	r.isAPIResource = false
 }
@@ -1,6 +1,7 @@
 package resourceful
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -140,6 +141,101 @@ func TestResource_processFilters(t *testing.T) {
 
 }
 
+func TestResource_processPatternFilters(t *testing.T) {
+	productResource.cleanState()
+	productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+	t.Run("error if field is not pattern filterable", func(t *testing.T) {
+		err := productResource.processFilters([]string{"product_type.name contains abc"})
+		require.Error(t, err)
+
+		err = productResource.processFilters([]string{"count startswith 1"})
+		require.Error(t, err)
+	})
+
+	t.Run("escape the pattern value", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFilters([]string{
+			"name like *steel_pipe*",
+			"name ilike '100% cotton*'",
+			"name startswith a\\b",
+			"name endswith _x",
+			`name contains "50%"`,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			`product."name" LIKE $1`,
+			`product."name" ILIKE $2`,
+			`product."name" ILIKE $3`,
+			`product."name" ILIKE $4`,
+			`product."name" ILIKE $5`,
+		}, productResource.whereStatements)
+		require.Equal(t, []any{`%steel\_pipe%`, `100\% cotton%`, `a\\b%`, `%\_x`, `%50\%%`}, productResource.queryArgs)
+	})
+}
+
+func TestResource_processNullAndRangeFilters(t *testing.T) {
+	t.Run("error if between field is not numeric or date", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFilters([]string{"name between a z"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if between has not two values", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFilters([]string{"count between 1"})
+		require.Error(t, err)
+
+		err = productResource.processFilters([]string{"count between (1 2 3)"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid value type on between & nin", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFilters([]string{"count between 1 a"})
+		require.Error(t, err)
+
+		err = productResource.processFilters([]string{"count nin (1 a)"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if unary operator has value", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFilters([]string{"name isnull abc"})
+		require.Error(t, err)
+	})
+
+	t.Run("no error if valid", func(t *testing.T) {
+		productResource.cleanState()
+		productResource.usedTablesMap = make(map[*Table]map[string]bool)
+
+		err := productResource.processFilters([]string{
+			"name isnull OR product_type.name notnull",
+			"count between 10 100",
+			"count between (10 100)",
+			"name nin (a 'b c')",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			`(product."name" IS NULL OR pt."name" IS NOT NULL)`,
+			`product."count" BETWEEN $1 AND $2`,
+			`product."count" BETWEEN $3 AND $4`,
+			`product."name" not in ($5,$6)`,
+		}, productResource.whereStatements)
+		require.Equal(t, []any{"10", "100", "10", "100", "a", "b c"}, productResource.queryArgs)
+	})
+}
+
 func TestResource_processLocalFilters(t *testing.T) {
 	productResource.cleanState()
 	productResource.usedTablesMap = make(map[*Table]map[string]bool)
@@ -169,6 +265,26 @@ func TestResource_processLocalFilters(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("error if expression", func(t *testing.T) {
+		err := productResource.processLocalFilters([]string{"company_id eq 1 OR company_id eq 2"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if IN has invalid format", func(t *testing.T) {
+		err := productResource.processLocalFilters([]string{"company_id in ()"})
+		require.Error(t, err)
+	})
+
+	t.Run("split the IN & NIN values", func(t *testing.T) {
+		productResource.whereStatements = nil
+		productResource.queryArgs = nil
+
+		err := productResource.processLocalFilters([]string{"company_id in (1 2)", "company_id nin 3 4"})
+		require.NoError(t, err)
+		require.Equal(t, []string{`product."company_id" in ($1,$2)`, `product."company_id" not in ($3,$4)`}, productResource.whereStatements)
+		require.Equal(t, []any{"1", "2", "3", "4"}, productResource.queryArgs)
+	})
+
 	require.Equal(t,
 		map[*Table]map[string]bool{
 			&productTable:     {FILTER: true},
@@ -179,6 +295,37 @@ func TestResource_processLocalFilters(t *testing.T) {
 
 }
 
+func TestResource_processFields(t *testing.T) {
+	t.Run("error if field is not selectable", func(t *testing.T) {
+		productResource.cleanState()
+
+		err := productResource.processFields([]string{"company_id"})
+		require.Error(t, err)
+
+		err = productResource.processFields([]string{"random_field"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if has duplicate field", func(t *testing.T) {
+		productResource.cleanState()
+
+		err := productResource.processFields([]string{"name", "name"})
+		require.Error(t, err)
+	})
+
+	t.Run("no error if valid", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Limit: 10, Page: 1, Fields: []string{"count", "name"}})
+		require.NoError(t, err)
+		require.Equal(t, []*Field{productTable.Field("count"), productTable.Field("name")}, productResource.SelectedFields())
+	})
+
+	t.Run("empty if no fields", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Limit: 10, Page: 1})
+		require.NoError(t, err)
+		require.Empty(t, productResource.SelectedFields())
+	})
+}
+
 func TestResource_sortStatement(t *testing.T) {
 	productResource.defaultSortFields = []Field{*productTable.Fields[5]}
 
@@ -234,3 +381,201 @@ func TestResource_GetQueryUri(t *testing.T) {
 		require.Equal(t, `?limit=10&page=10`, queryParam)
 	})
 }
+
+type fakeRows struct {
+	rows  [][]any
+	index int
+}
+
+func (f *fakeRows) Next() bool {
+	f.index++
+	return f.index <= len(f.rows)
+}
+
+func (f *fakeRows) Scan(dest ...any) error {
+	for key, value := range f.rows[f.index-1] {
+		switch dest := dest[key].(type) {
+		case *string:
+			*dest = value.(string)
+		case *int:
+			*dest = value.(int)
+		case *int64:
+			*dest = value.(int64)
+		case *any:
+			*dest = value
+		case sql.Scanner:
+			if err := dest.Scan(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeRows) Err() error {
+	return nil
+}
+
+func TestResource_CursorPagination(t *testing.T) {
+	t.Run("error if cursor used on page pagination", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Limit: 10, Page: 1, Cursor: "abc"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid pagination", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Pagination: "random", Limit: 10})
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid cursor", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Pagination: CURSOR_PAGINATION, Limit: 10, Cursor: "invalid cursor"})
+		require.Error(t, err)
+	})
+
+	t.Run("query without cursor", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Pagination: CURSOR_PAGINATION, Limit: 2, Sorts: []string{"name asc"}})
+		require.NoError(t, err)
+
+		query, args, err := productResource.CursorQueryAndArgs(productTable.Field("id"))
+		require.NoError(t, err)
+		require.Equal(t, `SELECT product."id", product."name", product."created_on" FROM product
+JOIN product_type pt ON product."product_type_id" = pt."id"
+WHERE product."is_deleted" is false
+ORDER BY product."name" ASC, product."created_on" DESC, product."id" ASC
+LIMIT $1`, query)
+		require.Equal(t, []any{3}, args)
+	})
+
+	t.Run("scan rows and continue with the next cursor", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Pagination: CURSOR_PAGINATION, Limit: 2, Sorts: []string{"name asc"}})
+		require.NoError(t, err)
+
+		ids, err := productResource.ScanCursorRows(&fakeRows{rows: [][]any{
+			{"1", "apple", "2023-01-01T00:00:00Z"},
+			{"2", nil, "2023-01-02T00:00:00Z"},
+			{"3", nil, "2023-01-03T00:00:00Z"},
+		}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "2"}, ids)
+
+		productResource.SetResult(Result[string, string]{Ids: ids, PaginatedResult: []string{"apple", "banana"}})
+		metadata := productResource.CursorMetadata()
+		require.True(t, metadata.HasNext)
+		require.NotEmpty(t, metadata.NextCursor)
+		require.Equal(t, 2, metadata.Count)
+
+		err = productResource.SetParam(Parameter{Pagination: CURSOR_PAGINATION, Limit: 2, Sorts: []string{"name asc"}, Cursor: metadata.NextCursor})
+		require.NoError(t, err)
+
+		query, args, err := productResource.CursorQueryAndArgs(productTable.Field("id"))
+		require.NoError(t, err)
+		require.Contains(t, query, `AND ((product."name" IS NULL AND product."created_on" < $1) OR (product."name" IS NULL AND product."created_on" = $1 AND product."id" > $2))`)
+		require.Equal(t, []any{"2023-01-02T00:00:00Z", "2", 3}, args)
+	})
+
+	t.Run("last page has no next cursor", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Pagination: CURSOR_PAGINATION, Limit: 2})
+		require.NoError(t, err)
+
+		ids, err := productResource.ScanCursorRows(&fakeRows{rows: [][]any{
+			{"1", "2023-01-01T00:00:00Z"},
+		}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"1"}, ids)
+		require.False(t, productResource.CursorMetadata().HasNext)
+	})
+
+	t.Run("error if cursor used with different sorts", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Pagination: CURSOR_PAGINATION, Limit: 1})
+		require.NoError(t, err)
+
+		_, err = productResource.ScanCursorRows(&fakeRows{rows: [][]any{
+			{"1", "2023-01-01T00:00:00Z"},
+			{"2", "2023-01-02T00:00:00Z"},
+		}})
+		require.NoError(t, err)
+
+		err = productResource.SetParam(Parameter{Pagination: CURSOR_PAGINATION, Limit: 1, Sorts: []string{"name desc"}, Cursor: productResource.CursorMetadata().NextCursor})
+		require.Error(t, err)
+	})
+}
+
+func TestResource_OffsetPagination(t *testing.T) {
+	t.Run("error if invalid pagination parameter", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Limit: 0, Page: 1})
+		require.NoError(t, err)
+
+		_, _, err = productResource.OffsetQueryAndArgs(productTable.Field("id"))
+		require.ErrorIs(t, err, ErrPagination)
+	})
+
+	t.Run("query with limit & offset", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Limit: 10, Page: 3, Filters: []string{"count gt 2"}})
+		require.NoError(t, err)
+
+		query, args, err := productResource.OffsetQueryAndArgs(productTable.Field("id"))
+		require.NoError(t, err)
+		require.Equal(t, `SELECT product."id", COUNT(*) OVER() FROM product
+JOIN product_type pt ON product."product_type_id" = pt."id"
+WHERE product."count" > $1
+AND product."is_deleted" is false
+ORDER BY product."created_on" DESC, product."id" ASC
+LIMIT $2 OFFSET $3`, query)
+		require.Equal(t, []any{"2", 10, 20}, args)
+	})
+
+	t.Run("order by the id without the sort", func(t *testing.T) {
+		noteTable := &Table{
+			Name:   "note",
+			Fields: []*Field{{Name: "id"}, {Name: "title", Type: STRING, Searchable: true, Sortable: true}},
+		}
+		noteDefinition, err := NewDefinition(noteTable)
+		require.NoError(t, err)
+
+		resource := NewResource[string, string](noteDefinition)
+		err = resource.SetParam(Parameter{Limit: 10, Page: 2})
+		require.NoError(t, err)
+
+		query, _, err := resource.OffsetQueryAndArgs(noteTable.Field("id"))
+		require.NoError(t, err)
+		require.Equal(t, `SELECT note."id", COUNT(*) OVER() FROM note
+ORDER BY note."id" ASC
+LIMIT $1 OFFSET $2`, query)
+
+		err = resource.SetParam(Parameter{Limit: 10, Page: 2, Sorts: []string{"title asc"}})
+		require.NoError(t, err)
+
+		query, _, err = resource.OffsetQueryAndArgs(noteTable.Field("id"))
+		require.NoError(t, err)
+		require.Contains(t, query, `ORDER BY note."title" ASC, note."id" ASC`)
+
+		resource.Select([]*Field{noteTable.Field("id"), noteTable.Field("title")})
+		query, _, err = resource.PopulateQueryArgs(noteTable.Field("id"), []string{"1", "2"})
+		require.NoError(t, err)
+		require.Contains(t, query, `ORDER BY note."title" ASC, note."id" ASC`)
+	})
+
+	t.Run("scan rows and set the total count", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Limit: 2, Page: 1})
+		require.NoError(t, err)
+
+		ids, err := productResource.ScanOffsetRows(&fakeRows{rows: [][]any{
+			{"1", 5},
+			{"2", 5},
+		}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "2"}, ids)
+
+		productResource.SetResult(Result[string, string]{Ids: ids, PaginatedResult: []string{"apple", "banana"}})
+		require.Equal(t, &Metadata{Count: 2, Page: 1, TotalCount: 5, TotalPage: 3}, productResource.Metadata())
+	})
+
+	t.Run("error if page out of range", func(t *testing.T) {
+		err := productResource.SetParam(Parameter{Limit: 2, Page: 4})
+		require.NoError(t, err)
+
+		_, err = productResource.ScanOffsetRows(&fakeRows{})
+		require.ErrorIs(t, err, ErrPagination)
+	})
+}
@@ -1,8 +1,9 @@
 package resourceful
 
 type Response[IDType, Model any] struct {
-	Metadata *Metadata           `json:"metadata"`
-	Data     Data[IDType, Model] `json:"data"`
+	Metadata any                     `json:"metadata"`
+	Data     Data[IDType, Model]     `json:"data"`
+	Facets   map[string][]FacetValue `json:"facets,omitempty"`
 }
 
 type Metadata struct {
@@ -12,7 +13,18 @@ type Metadata struct {
 	TotalPage  int `json:"total_page"`
 }
 
+type CursorMetadata struct {
+	Count      int    `json:"count"`
+	HasNext    bool   `json:"has_next"`
+	NextCursor string `json:"next_cursor"`
+}
+
 type Data[IDType, Model any] struct {
 	PaginatedResult []Model  `json:"paginated_result"`
 	Ids             []IDType `json:"ids"`
 }
+
+type Bucket struct {
+	Groups map[string]any `json:"groups"`
+	Values map[string]any `json:"values"`
+}
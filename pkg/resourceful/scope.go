@@ -0,0 +1,101 @@
+package resourceful
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Operator string
+
+const (
+	EQ      Operator = "eq"
+	NE      Operator = "ne"
+	LT      Operator = "lt"
+	LTE     Operator = "lte"
+	GT      Operator = "gt"
+	GTE     Operator = "gte"
+	IN      Operator = "in"
+	NIN     Operator = "nin"
+	BETWEEN Operator = "between"
+	ISNULL  Operator = "isnull"
+	NOTNULL Operator = "notnull"
+)
+
+type scope struct {
+	field    *Field
+	operator Operator
+	values   []any
+}
+
+// Add the server side constraint (e.g. tenant scope) to the Resource. The values are bound as the query args as is,
+// the scope is kept on SetParam and can't be seen or overridden from the Parameter
+func (r *Resource[IDType, Model]) Scope(field *Field, operator Operator, values ...any) error {
+	if r.isAPIResource {
+		return createError("scope can't be used on the API resource")
+	}
+	if field == nil || field.table == nil || !r.tableDefinition.hasTable(field.table) {
+		return createError("scope field must be a field of the resource definition")
+	}
+
+	switch {
+	case isUnaryFilterOperator(string(operator)):
+		if len(values) != 0 {
+			return createError(fmt.Sprintf(`"%s" scope must have no value`, operator))
+		}
+	case rangeFilterOperators[string(operator)] != "":
+		if len(values) != 2 {
+			return createError(fmt.Sprintf(`"%s" scope must have two values`, operator))
+		}
+	case multiValueFilterOperators[string(operator)] != "":
+		if len(values) == 0 {
+			return createError(fmt.Sprintf(`"%s" scope must have at least one value`, operator))
+		}
+	case filterOperators[string(operator)] != "":
+		if len(values) != 1 {
+			return createError(fmt.Sprintf(`"%s" scope must have one value`, operator))
+		}
+	default:
+		return createError(fmt.Sprintf(`invalid "%s" scope operator`, operator))
+	}
+
+	newScope := scope{field: field, operator: operator, values: values}
+	r.scopes = append(r.scopes, newScope)
+
+	// Already processed Resource must be scoped too
+	if r.isProcessed {
+		r.processScope(newScope)
+	}
+
+	return nil
+}
+
+func (r *Resource[IDType, Model]) processScopes() {
+	for _, scope := range r.scopes {
+		r.processScope(scope)
+	}
+}
+
+func (r *Resource[IDType, Model]) processScope(scope scope) {
+	operator := string(scope.operator)
+
+	var placeholders []string
+	for _, value := range scope.values {
+		r.queryArgs = append(r.queryArgs, value)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(r.queryArgs)))
+	}
+
+	var whereStatement string
+	switch {
+	case isUnaryFilterOperator(operator):
+		whereStatement = fmt.Sprintf("%s %s", scope.field.statement, unaryFilterOperators[operator])
+	case rangeFilterOperators[operator] != "":
+		whereStatement = fmt.Sprintf("%s %s %s AND %s", scope.field.statement, rangeFilterOperators[operator], placeholders[0], placeholders[1])
+	case multiValueFilterOperators[operator] != "":
+		whereStatement = fmt.Sprintf("%s %s (%s)", scope.field.statement, multiValueFilterOperators[operator], strings.Join(placeholders, ","))
+	default:
+		whereStatement = fmt.Sprintf("%s %s %s", scope.field.statement, filterOperators[operator], placeholders[0])
+	}
+
+	r.whereStatements = append(r.whereStatements, whereStatement)
+	r.useFieldFor(scope.field, FILTER)
+}
@@ -0,0 +1,62 @@
+package resourceful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_Scope(t *testing.T) {
+	productDefinition, err := NewDefinition(&productTable)
+	require.NoError(t, err)
+
+	t.Run("error if field is not on the definition", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+
+		err := resource.Scope(nil, EQ, 1)
+		require.Error(t, err)
+
+		err = resource.Scope(&Field{Name: "company_id"}, EQ, 1)
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid operator or values", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+
+		require.Error(t, resource.Scope(productTable.Field("company_id"), Operator("like"), "a"))
+		require.Error(t, resource.Scope(productTable.Field("company_id"), EQ))
+		require.Error(t, resource.Scope(productTable.Field("company_id"), EQ, 1, 2))
+		require.Error(t, resource.Scope(productTable.Field("company_id"), IN))
+		require.Error(t, resource.Scope(productTable.Field("company_id"), BETWEEN, 1))
+		require.Error(t, resource.Scope(productTable.Field("company_id"), ISNULL, 1))
+	})
+
+	t.Run("scope is kept on SetParam", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+
+		err := resource.Scope(productTable.Field("company_id"), EQ, int64(392))
+		require.NoError(t, err)
+		err = resource.Scope(productTypeTable.Field("id"), IN, 1, 2)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			err = resource.SetParam(Parameter{Limit: 10, Page: 1, LocalFilters: []string{"company_id eq 1"}})
+			require.NoError(t, err)
+
+			require.Equal(t, []string{`product."company_id" = $1`, `product."company_id" = $2`, `pt."id" in ($3,$4)`}, resource.whereStatements)
+			require.Equal(t, []any{"1", int64(392), 1, 2}, resource.queryArgs)
+			require.Equal(t, "?limit=10&page=1", resource.GetParamUri())
+		}
+	})
+
+	t.Run("scope after SetParam", func(t *testing.T) {
+		resource := NewResource[string, string](productDefinition)
+
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1})
+		require.NoError(t, err)
+		err = resource.Scope(productTable.Field("company_id"), NOTNULL)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{`product."company_id" IS NOT NULL`}, resource.whereStatements)
+	})
+}
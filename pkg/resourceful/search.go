@@ -0,0 +1,323 @@
+package resourceful
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Search strategies of the Definition
+const (
+	LIKE_SEARCH     = "like"
+	FULLTEXT_SEARCH = "fulltext"
+	TRIGRAM_SEARCH  = "trigram"
+)
+
+const (
+	DEFAULT_SEARCH_WEIGHT   = "D"
+	DEFAULT_SEARCH_LANGUAGE = "simple"
+)
+
+var searchWeights = map[string]bool{"A": true, "B": true, "C": true, "D": true}
+
+// The language is written to the statement as is, so only the regconfig name is allowed
+var searchLanguageRegexp = regexp.MustCompile(`^[a-z_]+$`)
+
+type DefinitionOption struct {
+	// LIKE_SEARCH (default), FULLTEXT_SEARCH or TRIGRAM_SEARCH, the TRIGRAM_SEARCH requires the pg_trgm extension
+	SearchStrategy string
+	// The named filters & sorts presets applied by the Parameter view
+	Views []View
+	// The query cost guardrails
+	Policy Policy
+}
+
+func validateSearchStrategy(searchStrategy string, searchFields []*Field) error {
+	switch searchStrategy {
+	case LIKE_SEARCH, TRIGRAM_SEARCH:
+	case FULLTEXT_SEARCH:
+		for _, field := range searchFields {
+			if field.SearchWeight != "" && !searchWeights[field.SearchWeight] {
+				return createError(fmt.Sprintf(`invalid search weight at "%s" field`, field.Name))
+			}
+			if field.SearchLanguage != "" && !searchLanguageRegexp.MatchString(field.SearchLanguage) {
+				return createError(fmt.Sprintf(`invalid search language at "%s" field`, field.Name))
+			}
+		}
+	default:
+		return createError(fmt.Sprintf(`invalid "%s" search strategy`, searchStrategy))
+	}
+
+	return nil
+}
+
+// Build the weighted tsvector of the search fields, the same expression must be used for the index, e.g.
+//
+//	setweight(to_tsvector('simple', coalesce(product."name", '')), 'A') || ...
+func searchVectorStatement(fields []*Field, language string) string {
+	var vectorStatements []string
+	for _, field := range fields {
+		weight := field.SearchWeight
+		if weight == "" {
+			weight = DEFAULT_SEARCH_WEIGHT
+		}
+
+		vectorStatements = append(vectorStatements, fmt.Sprintf(
+			"setweight(to_tsvector('%s', coalesce(%s, '')), '%s')",
+			language, searchTextStatement(field), weight,
+		))
+	}
+
+	return strings.Join(vectorStatements, " || ")
+}
+
+func searchTextStatement(field *Field) string {
+	if field.Type == STRING {
+		return field.statement
+	}
+
+	return field.statement + "::text"
+}
+
+// The untyped field is matched as is (e.g. the text column without the Type)
+func likeTextStatement(field *Field) string {
+	if field.Type == "" {
+		return field.statement
+	}
+
+	return searchTextStatement(field)
+}
+
+func searchLanguage(field *Field) string {
+	if field.SearchLanguage == "" {
+		return DEFAULT_SEARCH_LANGUAGE
+	}
+
+	return field.SearchLanguage
+}
+
+// Targeted search term, e.g. name:"steel pipe"
+type searchTerm struct {
+	key      string
+	value    string
+	position int
+}
+
+var searchTermRegexp = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_.]*):(.*)$`)
+
+// Split the search param into the free text & the targeted search terms. The quoted value
+// is kept as a single token, the term position is the 1-based position in the search param.
+// Only the token of the search key is the search term, e.g. "Note:" or "http://host" is kept as the free text
+func parseSearch(searchParam string, isSearchKey func(key string) bool) (string, []searchTerm) {
+	var (
+		freeTexts   []string
+		searchTerms []searchTerm
+	)
+
+	for lo := 0; lo < len(searchParam); {
+		if searchParam[lo] == ' ' || searchParam[lo] == '\t' || searchParam[lo] == '\n' {
+			lo++
+			continue
+		}
+
+		hi := lo
+		for hi < len(searchParam) && !strings.ContainsRune(" \t\n", rune(searchParam[hi])) {
+			if searchParam[hi] == '"' {
+				// The unterminated quote is kept until the end of the search param
+				end := strings.IndexByte(searchParam[hi+1:], '"')
+				if end == -1 {
+					hi = len(searchParam)
+					break
+				}
+				hi += end + 1
+			}
+			hi++
+		}
+
+		token := searchParam[lo:hi]
+		if match := searchTermRegexp.FindStringSubmatch(token); match != nil && isSearchKey(match[1]) {
+			value := match[2]
+			if strings.HasPrefix(value, `"`) {
+				value = strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+			}
+
+			searchTerms = append(searchTerms, searchTerm{key: match[1], value: strings.TrimSpace(value), position: lo + 1})
+		} else {
+			freeTexts = append(freeTexts, token)
+		}
+
+		lo = hi
+	}
+
+	return strings.Join(freeTexts, " "), searchTerms
+}
+
+// Get the search field by the field key or the "table.field" key
+func (r *Resource[IDType, Model]) searchField(key string) (*Field, bool) {
+	for _, searchField := range r.searchFields {
+		if fieldKey(searchField) == key {
+			return searchField, true
+		}
+	}
+	for _, searchField := range r.searchFields {
+		if fmt.Sprintf("%s.%s", searchField.table.Name, searchField.Name) == key {
+			return searchField, true
+		}
+	}
+
+	return nil, false
+}
+
+func (r *Resource[IDType, Model]) isSearchKey(key string) bool {
+	_, ok := r.searchField(key)
+
+	return ok
+}
+
+// Build the search where statement & the rank statement (empty if not ranked) of the fields by the search strategy
+func (r *Resource[IDType, Model]) searchStatement(fields []*Field, searchParam string) (string, string) {
+	switch r.searchStrategy {
+	case FULLTEXT_SEARCH:
+		return r.fullTextSearchStatement(fields, searchParam)
+	case TRIGRAM_SEARCH:
+		return r.trigramSearchStatement(fields, searchParam)
+	}
+
+	return r.likeSearchStatement(fields, searchParam), ""
+}
+
+// The search fields are matched by websearch_to_tsquery per language & ranked by the sum of ts_rank.
+// The single NUMERIC or INTEGER field (targeted search) is matched by equality
+func (r *Resource[IDType, Model]) fullTextSearchStatement(fields []*Field, searchParam string) (string, string) {
+	if len(fields) == 1 && isNumericSearchType(fields[0].Type) {
+		return r.numericSearchStatement(fields, searchParam), ""
+	}
+
+	var (
+		languages      []string
+		languageFields = make(map[string][]*Field)
+	)
+	for _, searchField := range fields {
+		language := searchLanguage(searchField)
+		if _, ok := languageFields[language]; !ok {
+			languages = append(languages, language)
+		}
+
+		languageFields[language] = append(languageFields[language], searchField)
+		r.useFieldFor(searchField, SEARCH)
+	}
+
+	r.queryArgs = append(r.queryArgs, searchParam)
+	placeholder := fmt.Sprintf("$%d", len(r.queryArgs))
+
+	var (
+		searchStatements []string
+		rankStatements   []string
+	)
+	for _, language := range languages {
+		vectorStatement := searchVectorStatement(languageFields[language], language)
+		queryStatement := fmt.Sprintf("websearch_to_tsquery('%s', %s)", language, placeholder)
+
+		searchStatements = append(searchStatements, fmt.Sprintf("(%s) @@ %s", vectorStatement, queryStatement))
+		rankStatements = append(rankStatements, fmt.Sprintf("ts_rank(%s, %s)", vectorStatement, queryStatement))
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(searchStatements, " OR ")), strings.Join(rankStatements, " + ")
+}
+
+// The search fields are matched by the pg_trgm similarity operator & ranked by the greatest similarity.
+// The NUMERIC & INTEGER fields are matched by equality
+func (r *Resource[IDType, Model]) trigramSearchStatement(fields []*Field, searchParam string) (string, string) {
+	var (
+		stringFields     []*Field
+		searchStatements []string
+		rankStatements   []string
+	)
+	for _, searchField := range fields {
+		if !isNumericSearchType(searchField.Type) {
+			stringFields = append(stringFields, searchField)
+		}
+	}
+
+	if numericStatement := r.numericSearchStatement(fields, searchParam); numericStatement != "" {
+		searchStatements = append(searchStatements, numericStatement)
+	}
+
+	if len(stringFields) > 0 {
+		r.queryArgs = append(r.queryArgs, searchParam)
+		placeholder := fmt.Sprintf("$%d", len(r.queryArgs))
+
+		for _, searchField := range stringFields {
+			r.useFieldFor(searchField, SEARCH)
+
+			searchStatements = append(searchStatements, fmt.Sprintf("%s %% %s", searchTextStatement(searchField), placeholder))
+			rankStatements = append(rankStatements, fmt.Sprintf("similarity(%s, %s)", searchTextStatement(searchField), placeholder))
+		}
+	}
+
+	var rankStatement string
+	switch len(rankStatements) {
+	case 0:
+	case 1:
+		rankStatement = rankStatements[0]
+	default:
+		rankStatement = fmt.Sprintf("GREATEST(%s)", strings.Join(rankStatements, ", "))
+	}
+
+	return joinSearchStatements(searchStatements), rankStatement
+}
+
+// The search fields are matched by the case insensitive LIKE on the text (e.g. the UUID & ENUM field is cast to the text),
+// the NUMERIC & INTEGER fields are matched by equality
+func (r *Resource[IDType, Model]) likeSearchStatement(fields []*Field, searchParam string) string {
+	var searchStatements []string
+
+	if numericStatement := r.numericSearchStatement(fields, searchParam); numericStatement != "" {
+		searchStatements = append(searchStatements, numericStatement)
+	}
+
+	for _, searchField := range fields {
+		if !isNumericSearchType(searchField.Type) {
+			r.useFieldFor(searchField, SEARCH)
+			r.queryArgs = append(r.queryArgs, "%"+strings.ToLower(searchParam)+"%")
+			searchStatements = append(searchStatements, fmt.Sprintf("lower(%s) like $%d", likeTextStatement(searchField), len(r.queryArgs)))
+		}
+	}
+
+	return joinSearchStatements(searchStatements)
+}
+
+// Match the NUMERIC fields by equality only on the numeric search & the INTEGER fields only on the integer search,
+// empty if nothing to match
+func (r *Resource[IDType, Model]) numericSearchStatement(fields []*Field, searchParam string) string {
+	if _, err := strconv.ParseFloat(searchParam, 64); err != nil {
+		return ""
+	}
+	_, err := strconv.ParseInt(searchParam, 10, 64)
+	isInteger := err == nil
+
+	var searchStatements []string
+	for _, searchField := range fields {
+		if searchField.Type == NUMERIC || (searchField.Type == INTEGER && isInteger) {
+			r.useFieldFor(searchField, SEARCH)
+			r.queryArgs = append(r.queryArgs, searchParam)
+			searchStatements = append(searchStatements, fmt.Sprintf("%s = $%d", searchField.statement, len(r.queryArgs)))
+		}
+	}
+
+	return strings.Join(searchStatements, " OR ")
+}
+
+func isNumericSearchType(fieldType string) bool {
+	return fieldType == NUMERIC || fieldType == INTEGER
+}
+
+// Nothing is matched if there is no search statement (e.g. the text search on the NUMERIC fields only)
+func joinSearchStatements(searchStatements []string) string {
+	if len(searchStatements) == 0 {
+		return "false"
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(searchStatements, " OR "))
+}
@@ -0,0 +1,190 @@
+package resourceful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefinition_SearchStrategy(t *testing.T) {
+	t.Run("error if invalid search strategy", func(t *testing.T) {
+		table := &Table{Name: "article", Fields: []*Field{{Name: "title", Type: STRING, Searchable: true}}}
+
+		_, err := NewDefinition(table, DefinitionOption{SearchStrategy: "random"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid search weight or language", func(t *testing.T) {
+		table := &Table{Name: "article", Fields: []*Field{{Name: "title", Type: STRING, Searchable: true, SearchWeight: "E"}}}
+		_, err := NewDefinition(table, DefinitionOption{SearchStrategy: FULLTEXT_SEARCH})
+		require.Error(t, err)
+
+		table = &Table{Name: "article", Fields: []*Field{{Name: "title", Type: STRING, Searchable: true, SearchLanguage: "english'); --"}}}
+		_, err = NewDefinition(table, DefinitionOption{SearchStrategy: FULLTEXT_SEARCH})
+		require.Error(t, err)
+	})
+}
+
+func TestResource_FullTextSearch(t *testing.T) {
+	articleTable := &Table{
+		Name: "article",
+		Fields: []*Field{
+			{Name: "id"},
+			{Name: "title", Type: STRING, Searchable: true, SearchWeight: "A", Sortable: true},
+			{Name: "body", Type: STRING, Searchable: true, SearchLanguage: "english"},
+			{Name: "views", Type: NUMERIC, Searchable: true},
+		},
+	}
+	articleDefinition, err := NewDefinition(articleTable, DefinitionOption{SearchStrategy: FULLTEXT_SEARCH})
+	require.NoError(t, err)
+
+	t.Run("match per language & order by rank", func(t *testing.T) {
+		resource := NewResource[string, string](articleDefinition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Search: `"fresh fruit" -apple`})
+		require.NoError(t, err)
+
+		query, args, err := resource.OffsetQueryAndArgs(articleTable.Field("id"))
+		require.NoError(t, err)
+		require.Equal(t, `SELECT article."id", COUNT(*) OVER() FROM article
+WHERE ((setweight(to_tsvector('simple', coalesce(article."title", '')), 'A') || setweight(to_tsvector('simple', coalesce(article."views"::text, '')), 'D')) @@ websearch_to_tsquery('simple', $1) OR (setweight(to_tsvector('english', coalesce(article."body", '')), 'D')) @@ websearch_to_tsquery('english', $1))
+ORDER BY ts_rank(setweight(to_tsvector('simple', coalesce(article."title", '')), 'A') || setweight(to_tsvector('simple', coalesce(article."views"::text, '')), 'D'), websearch_to_tsquery('simple', $1)) + ts_rank(setweight(to_tsvector('english', coalesce(article."body", '')), 'D'), websearch_to_tsquery('english', $1)) DESC, article."id" ASC
+LIMIT $2 OFFSET $3`, query)
+		require.Equal(t, []any{`"fresh fruit" -apple`, 10, 0}, args)
+	})
+
+	t.Run("search term only on its field", func(t *testing.T) {
+		resource := NewResource[string, string](articleDefinition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "title:fruit views:20"})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			`((setweight(to_tsvector('simple', coalesce(article."title", '')), 'A')) @@ websearch_to_tsquery('simple', $1))`,
+			`article."views" = $2`,
+		}, resource.whereStatements)
+		require.Equal(t, []string{
+			`ts_rank(setweight(to_tsvector('simple', coalesce(article."title", '')), 'A'), websearch_to_tsquery('simple', $1)) DESC`,
+		}, resource.sortStatements)
+	})
+
+	t.Run("sorts param override the rank", func(t *testing.T) {
+		resource := NewResource[string, string](articleDefinition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "fruit", Sorts: []string{"title asc"}})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{`article."title" ASC`}, resource.sortStatements)
+	})
+
+	t.Run("rank args are rebound on the populate query", func(t *testing.T) {
+		resource := NewResource[string, string](articleDefinition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "fruit"})
+		require.NoError(t, err)
+
+		resource.Select([]*Field{articleTable.Field("id"), articleTable.Field("title")})
+		query, args, err := resource.PopulateQueryArgs(articleTable.Field("id"), []string{"1", "2"})
+		require.NoError(t, err)
+		require.Contains(t, query, `WHERE article."id" IN ($1, $2)`)
+		require.Contains(t, query, `websearch_to_tsquery('simple', $3)`)
+		require.NotContains(t, query, "$4")
+		require.Equal(t, []any{"1", "2", "fruit"}, args)
+	})
+}
+
+func TestResource_TrigramSearch(t *testing.T) {
+	articleTable := &Table{
+		Name: "article",
+		Fields: []*Field{
+			{Name: "id"},
+			{Name: "title", Type: STRING, Searchable: true},
+			{Name: "body", Type: STRING, Searchable: true},
+		},
+	}
+	articleDefinition, err := NewDefinition(articleTable, DefinitionOption{SearchStrategy: TRIGRAM_SEARCH})
+	require.NoError(t, err)
+
+	resource := NewResource[string, string](articleDefinition)
+	err = resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "frut"})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{`(article."title" % $1 OR article."body" % $1)`}, resource.whereStatements)
+	require.Equal(t, []string{`GREATEST(similarity(article."title", $1), similarity(article."body", $1)) DESC`}, resource.sortStatements)
+	require.Equal(t, []any{"frut"}, resource.queryArgs)
+}
+
+func TestParseSearch(t *testing.T) {
+	isSearchKey := func(key string) bool {
+		return key == "name" || key == "description" || key == "price"
+	}
+
+	freeText, searchTerms := parseSearch(`name:"steel pipe"  description:bolt fresh "red apple" 10:30 price:`, isSearchKey)
+	require.Equal(t, `fresh "red apple" 10:30`, freeText)
+	require.Equal(t, []searchTerm{
+		{key: "name", value: "steel pipe", position: 1},
+		{key: "description", value: "bolt", position: 20},
+		{key: "price", value: "", position: 61},
+	}, searchTerms)
+
+	freeText, searchTerms = parseSearch(`name:"steel pipe`, isSearchKey)
+	require.Empty(t, freeText)
+	require.Equal(t, []searchTerm{{key: "name", value: "steel pipe", position: 1}}, searchTerms)
+
+	freeText, searchTerms = parseSearch(`Note: see http://host/pipe ratio:2 name:bolt`, isSearchKey)
+	require.Equal(t, `Note: see http://host/pipe ratio:2`, freeText)
+	require.Equal(t, []searchTerm{{key: "name", value: "bolt", position: 36}}, searchTerms)
+}
+
+func TestResource_TargetedSearch(t *testing.T) {
+	articleTable := &Table{
+		Name: "article",
+		Fields: []*Field{
+			{Name: "id"},
+			{Name: "title", Type: STRING, Searchable: true},
+			{Name: "body", Type: STRING, Searchable: true},
+			{Name: "views", Type: NUMERIC, Searchable: true},
+		},
+	}
+	articleDefinition, err := NewDefinition(articleTable)
+	require.NoError(t, err)
+
+	t.Run("error if invalid search term", func(t *testing.T) {
+		resource := NewResource[string, string](articleDefinition)
+
+		err := resource.SetParam(Parameter{Search: "fruit title:"})
+		require.Equal(t, ValidationErrors{{FieldName: "search", Errors: []string{"missing value at position 7"}}}, err)
+
+		err = resource.SetParam(Parameter{Search: "views:many"})
+		require.Equal(t, ValidationErrors{{FieldName: "search", Errors: []string{"value must be a valid numeric format at position 1"}}}, err)
+	})
+
+	t.Run("search term only on its field", func(t *testing.T) {
+		resource := NewResource[string, string](articleDefinition)
+
+		err := resource.SetParam(Parameter{Search: `fresh title:"Red Apple" views:20`})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			`(lower(article."title") like $1 OR lower(article."body") like $2)`,
+			`(lower(article."title") like $3)`,
+			`(article."views" = $4)`,
+		}, resource.whereStatements)
+		require.Equal(t, []any{"%fresh%", "%fresh%", "%red apple%", "20"}, resource.queryArgs)
+	})
+
+	t.Run("unknown key is the free text", func(t *testing.T) {
+		for _, search := range []string{"author:bob", "http://host/apple"} {
+			resource := NewResource[string, string](articleDefinition)
+
+			err := resource.SetParam(Parameter{Search: search})
+			require.NoError(t, err)
+			require.Equal(t, []string{`(lower(article."title") like $1 OR lower(article."body") like $2)`}, resource.whereStatements)
+			require.Equal(t, []any{"%" + search + "%", "%" + search + "%"}, resource.queryArgs)
+		}
+	})
+
+	t.Run("numeric free text only match by equality", func(t *testing.T) {
+		resource := NewResource[string, string](articleDefinition)
+
+		err := resource.SetParam(Parameter{Search: "20"})
+		require.NoError(t, err)
+		require.Equal(t, []string{`(article."views" = $1 OR lower(article."title") like $2 OR lower(article."body") like $3)`}, resource.whereStatements)
+		require.Equal(t, []any{"20", "%20%", "%20%"}, resource.queryArgs)
+	})
+}
@@ -0,0 +1,101 @@
+package resourceful
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Soft delete modes of the Resource table
+const (
+	EXCLUDE_DELETED = "exclude"
+	INCLUDE_DELETED = "include"
+	ONLY_DELETED    = "only"
+)
+
+// The soft delete where statement of the field, the DATE field is deleted when it is not NULL
+func softDeleteStatement(field *Field, isDeleted bool) string {
+	if field.Type == DATE {
+		if isDeleted {
+			return fmt.Sprintf("%s IS NOT NULL", field.statement)
+		}
+		return fmt.Sprintf("%s IS NULL", field.statement)
+	}
+
+	if isDeleted {
+		return fmt.Sprintf("%s is true", field.statement)
+	}
+	return fmt.Sprintf("%s is false", field.statement)
+}
+
+// Set which soft deleted rows of the Resource table are queried (e.g. the admin trash view), the deleted rows
+// are excluded by default. Only the Resource table is affected, the deleted rows of the relations are still excluded.
+// The mode is kept on SetParam and can't be seen or overridden from the Parameter
+func (r *Resource[IDType, Model]) SetSoftDeleteMode(mode string) error {
+	if r.isAPIResource {
+		return createError("soft delete mode can't be used on the API resource")
+	}
+
+	softDeleteFields := r.tableDefinition.softDeleteFields()
+	if len(softDeleteFields) == 0 {
+		return createError(fmt.Sprintf(`"%s" table has no soft delete field`, r.tableDefinition.Name))
+	}
+
+	if mode != EXCLUDE_DELETED && mode != INCLUDE_DELETED && mode != ONLY_DELETED {
+		return createError(fmt.Sprintf(`invalid "%s" soft delete mode`, mode))
+	}
+
+	// Replace the previous mode statements of the Resource table, the statements are kept first as on the Definition
+	previousStatements := make(map[string]bool)
+	for _, statement := range softDeleteModeStatements(softDeleteFields, r.softDeleteMode) {
+		previousStatements[statement] = true
+	}
+
+	defaultWhereStatements := softDeleteModeStatements(softDeleteFields, mode)
+	for _, statement := range r.defaultWhereStatements {
+		if !previousStatements[statement] {
+			defaultWhereStatements = append(defaultWhereStatements, statement)
+		}
+	}
+
+	r.defaultWhereStatements = defaultWhereStatements
+	r.softDeleteMode = mode
+
+	return nil
+}
+
+// Get the soft delete fields of the table itself, the relation tables are not included
+func (table *Table) softDeleteFields() []*Field {
+	var softDeleteFields []*Field
+	for _, field := range table.Fields {
+		if field.SoftDeleteField {
+			softDeleteFields = append(softDeleteFields, field)
+		}
+	}
+
+	return softDeleteFields
+}
+
+// The where statements of the soft delete mode, the empty mode is the default EXCLUDE_DELETED mode
+func softDeleteModeStatements(softDeleteFields []*Field, mode string) []string {
+	var statements []string
+
+	switch mode {
+	case "", EXCLUDE_DELETED:
+		for _, field := range softDeleteFields {
+			statements = append(statements, softDeleteStatement(field, false))
+		}
+	case ONLY_DELETED:
+		// The row is deleted by any of the soft delete fields
+		var deletedStatements []string
+		for _, field := range softDeleteFields {
+			deletedStatements = append(deletedStatements, softDeleteStatement(field, true))
+		}
+		if len(deletedStatements) == 1 {
+			statements = deletedStatements
+		} else {
+			statements = append(statements, fmt.Sprintf("(%s)", strings.Join(deletedStatements, " OR ")))
+		}
+	}
+
+	return statements
+}
@@ -0,0 +1,108 @@
+package resourceful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_SetSoftDeleteMode(t *testing.T) {
+	categoryTable := Table{
+		Name:  "category",
+		Alias: "c",
+		Fields: []*Field{
+			{Name: "id"},
+			{Name: "name", Type: STRING, Searchable: true, Selectable: true},
+			{Name: "is_deleted", SoftDeleteField: true},
+		},
+	}
+	itemTable := Table{
+		Name: "item",
+		Fields: []*Field{
+			{Name: "id"},
+			{Name: "name", Type: STRING, Searchable: true, Selectable: true},
+			{Name: "category_id"},
+			{Name: "deleted_on", Type: DATE, SoftDeleteField: true},
+		},
+	}
+	itemTable.Relations = []*Relation{
+		{
+			IsMandatory:       true,
+			Table:             &categoryTable,
+			ForeignKeyField:   itemTable.Field("category_id"),
+			ReferenceKeyField: categoryTable.Field("id"),
+		},
+	}
+
+	itemDefinition, err := NewDefinition(&itemTable)
+	require.NoError(t, err)
+
+	queryWhere := func(resource *Resource[string, string]) string {
+		require.NoError(t, resource.SetParam(Parameter{Limit: 10, Page: 1}))
+		resource.Select([]*Field{itemTable.Field("id")})
+
+		query, _, err := resource.QueryAndArgs()
+		require.NoError(t, err)
+
+		return query
+	}
+
+	t.Run("exclude the deleted rows by default", func(t *testing.T) {
+		resource := NewResource[string, string](itemDefinition)
+
+		require.Equal(t, `SELECT item."id" FROM item
+JOIN category c ON item."category_id" = c."id"
+WHERE item."deleted_on" IS NULL
+AND c."is_deleted" is false`, queryWhere(resource))
+	})
+
+	t.Run("include the deleted rows of the resource table only", func(t *testing.T) {
+		resource := NewResource[string, string](itemDefinition)
+		require.NoError(t, resource.SetSoftDeleteMode(INCLUDE_DELETED))
+
+		require.Equal(t, `SELECT item."id" FROM item
+JOIN category c ON item."category_id" = c."id"
+WHERE c."is_deleted" is false`, queryWhere(resource))
+	})
+
+	t.Run("only the deleted rows & the mode is kept on SetParam", func(t *testing.T) {
+		resource := NewResource[string, string](itemDefinition)
+		require.NoError(t, resource.SetSoftDeleteMode(INCLUDE_DELETED))
+		require.NoError(t, resource.SetSoftDeleteMode(ONLY_DELETED))
+
+		for i := 0; i < 2; i++ {
+			require.Equal(t, `SELECT item."id" FROM item
+JOIN category c ON item."category_id" = c."id"
+WHERE item."deleted_on" IS NOT NULL
+AND c."is_deleted" is false`, queryWhere(resource))
+		}
+
+		require.NoError(t, resource.SetSoftDeleteMode(EXCLUDE_DELETED))
+		require.Equal(t, []string{`item."deleted_on" IS NULL`, `c."is_deleted" is false`}, resource.defaultWhereStatements)
+	})
+
+	t.Run("the definition is not changed", func(t *testing.T) {
+		resource := NewResource[string, string](itemDefinition)
+		require.NoError(t, resource.SetSoftDeleteMode(ONLY_DELETED))
+
+		require.Equal(t, []string{`item."deleted_on" IS NULL`, `c."is_deleted" is false`}, itemDefinition.defaultWhereStatements)
+	})
+
+	t.Run("error if invalid mode or no soft delete field", func(t *testing.T) {
+		require.Error(t, NewResource[string, string](itemDefinition).SetSoftDeleteMode("all"))
+
+		categoryDefinition, err := NewDefinition(&Table{Name: "category", Fields: []*Field{{Name: "name", Type: STRING, Searchable: true}}})
+		require.NoError(t, err)
+		require.Error(t, NewResource[string, string](categoryDefinition).SetSoftDeleteMode(ONLY_DELETED))
+
+		require.Error(t, NewAPIResource[string, string](Parameter{}).SetSoftDeleteMode(ONLY_DELETED))
+	})
+
+	t.Run("error if invalid soft delete field type", func(t *testing.T) {
+		_, err := NewDefinition(&Table{Name: "category", Fields: []*Field{
+			{Name: "name", Type: STRING, Searchable: true},
+			{Name: "deleted_by", Type: STRING, SoftDeleteField: true},
+		}})
+		require.Error(t, err)
+	})
+}
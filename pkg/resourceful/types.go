@@ -1,18 +1,46 @@
 package resourceful
 
 import (
+	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 const (
 	NUMERIC = "numeric"
+	INTEGER = "integer"
 	BOOLEAN = "boolean"
 	STRING  = "string"
 	DATE    = "date"
+	UUID    = "uuid"
+	ENUM    = "enum"  // The allowed values are the Field Values
+	JSONB   = "jsonb" // Only filterable by the path, e.g. attributes.color eq red
 )
 
+// Date-only filter value
+const dateOnlyLayout = "2006-01-02"
+
+// Relative date filter value, e.g. now, now-7d or now+1h
+var relativeDateRegexp = regexp.MustCompile(`^now(?:([+-])(\d+)([smhdw]))?$`)
+
+var relativeDateUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// The JSONB path segment is written to the statement as is, so only the key characters are allowed
+var jsonbPathSegmentRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Replaced on test
+var timeNow = time.Now
+
 func sanitizeFilterValueByType(value, filterType string) ([]string, bool) {
 	value, _ = strings.CutPrefix(value, "(")
 	value, _ = strings.CutSuffix(value, ")")
@@ -62,13 +90,23 @@ func sanitizeFilterValueByType(value, filterType string) ([]string, bool) {
 			if err != nil {
 				return nil, false
 			}
-
+		case INTEGER:
+			_, err := strconv.ParseInt(separatedValue, 10, 64)
+			if err != nil {
+				return nil, false
+			}
 		case BOOLEAN:
 			if separatedValue != "true" && separatedValue != "false" {
 				return nil, false
 			}
 		case DATE:
-			_, err := time.Parse(time.RFC3339, separatedValue)
+			dateValue, ok := parseDateValue(separatedValue)
+			if !ok {
+				return nil, false
+			}
+			separatedValue = dateValue
+		case UUID:
+			_, err := uuid.Parse(separatedValue)
 			if err != nil {
 				return nil, false
 			}
@@ -79,3 +117,68 @@ func sanitizeFilterValueByType(value, filterType string) ([]string, bool) {
 
 	return sanitizedValues, true
 }
+
+// Parse the RFC3339, date-only or relative date, the relative date is resolved to the RFC3339 time
+func parseDateValue(value string) (string, bool) {
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return value, true
+	}
+	if _, err := time.Parse(dateOnlyLayout, value); err == nil {
+		return value, true
+	}
+
+	match := relativeDateRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return "", false
+	}
+
+	date := timeNow()
+	if match[1] != "" {
+		amount, err := strconv.Atoi(match[2])
+		if err != nil {
+			return "", false
+		}
+
+		offset := time.Duration(amount) * relativeDateUnits[match[3]]
+		if match[1] == "-" {
+			offset = -offset
+		}
+		date = date.Add(offset)
+	}
+
+	return date.UTC().Format(time.RFC3339), true
+}
+
+// The filter value validation message of the field type
+func filterValueErrorMessage(field *Field) string {
+	switch field.Type {
+	case INTEGER:
+		return "value must be a valid integer"
+	case BOOLEAN:
+		return "value must be true or false"
+	case DATE:
+		return "value must be a valid RFC3339, YYYY-MM-DD or relative (e.g. now-7d) date"
+	case UUID:
+		return "value must be a valid uuid"
+	case ENUM:
+		return fmt.Sprintf("value must be one of %s", strings.Join(field.Values, ", "))
+	}
+
+	return fmt.Sprintf("value must be a valid %s format", field.Type)
+}
+
+// Check if the value is one of the ENUM field values
+func validEnumValue(field *Field, value string) bool {
+	for _, enumValue := range field.Values {
+		if enumValue == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Range operators are only available on the ordered type
+func isRangeType(fieldType string) bool {
+	return fieldType == NUMERIC || fieldType == INTEGER || fieldType == DATE
+}
@@ -2,6 +2,7 @@ package resourceful
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -48,3 +49,183 @@ func TestResource_sanitizeFilterValueByType(t *testing.T) {
 	})
 
 }
+
+func TestResource_sanitizeFilterValueByType_Types(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+
+	testCases := []struct {
+		name           string
+		value          string
+		filterType     string
+		expectedValues []string
+		expectedOk     bool
+	}{
+		{name: "integer", value: "1 -20", filterType: INTEGER, expectedValues: []string{"1", "-20"}, expectedOk: true},
+		{name: "integer float", value: "1.5", filterType: INTEGER},
+		{name: "uuid", value: "f47ac10b-58cc-4372-a567-0e02b2c3d479", filterType: UUID, expectedValues: []string{"f47ac10b-58cc-4372-a567-0e02b2c3d479"}, expectedOk: true},
+		{name: "invalid uuid", value: "f47ac10b", filterType: UUID},
+		{name: "rfc3339 date", value: "2024-01-02T15:04:05Z", filterType: DATE, expectedValues: []string{"2024-01-02T15:04:05Z"}, expectedOk: true},
+		{name: "date-only", value: "2024-01-02", filterType: DATE, expectedValues: []string{"2024-01-02"}, expectedOk: true},
+		{name: "relative date", value: "now-7d now+1h now", filterType: DATE, expectedValues: []string{"2024-03-03T08:00:00Z", "2024-03-10T09:00:00Z", "2024-03-10T08:00:00Z"}, expectedOk: true},
+		{name: "invalid relative date", value: "now-7y", filterType: DATE},
+		{name: "invalid date", value: "02-01-2024", filterType: DATE},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			values, ok := sanitizeFilterValueByType(testCase.value, testCase.filterType)
+			require.Equal(t, testCase.expectedOk, ok)
+			require.Equal(t, testCase.expectedValues, values)
+		})
+	}
+}
+
+func newTypedTable() *Table {
+	return &Table{
+		Name: "item",
+		Fields: []*Field{
+			{Name: "id", Type: UUID, Filterable: true},
+			{Name: "name", Type: STRING, Searchable: true},
+			{Name: "quantity", Type: INTEGER, Filterable: true},
+			{Name: "status", Type: ENUM, Values: []string{"draft", "published"}, Filterable: true, Selectable: true},
+			{Name: "attributes", Type: JSONB, Filterable: true, Selectable: true},
+			{Name: "created_on", Type: DATE, Filterable: true},
+		},
+	}
+}
+
+func TestNewDefinition_Types(t *testing.T) {
+	t.Run("error if enum field has no values", func(t *testing.T) {
+		table := newTypedTable()
+		table.Field("status").Values = nil
+
+		_, err := NewDefinition(table)
+		require.Error(t, err)
+	})
+
+	t.Run("error if values on non enum field", func(t *testing.T) {
+		table := newTypedTable()
+		table.Field("quantity").Values = []string{"1"}
+
+		_, err := NewDefinition(table)
+		require.Error(t, err)
+	})
+
+	t.Run("error if jsonb field is sortable", func(t *testing.T) {
+		table := newTypedTable()
+		table.Field("attributes").Sortable = true
+
+		_, err := NewDefinition(table)
+		require.Error(t, err)
+	})
+}
+
+func TestResource_Types(t *testing.T) {
+	typedTable := newTypedTable()
+	definition, err := NewDefinition(typedTable)
+	require.NoError(t, err)
+
+	testErrors := []struct {
+		filter          string
+		expectedMessage string
+	}{
+		{filter: "id eq 123", expectedMessage: "value must be a valid uuid at position 1"},
+		{filter: "quantity eq 1.5", expectedMessage: "value must be a valid integer at position 1"},
+		{filter: "status in draft archived", expectedMessage: "value must be one of draft, published at position 1"},
+		{filter: "created_on gt yesterday", expectedMessage: "value must be a valid RFC3339, YYYY-MM-DD or relative (e.g. now-7d) date at position 1"},
+		{filter: "attributes eq red", expectedMessage: "jsonb field must be filtered by the path, e.g. attributes.key at position 1"},
+		{filter: "attributes.color gt red", expectedMessage: "operator not available on jsonb path at position 1"},
+		{filter: "attributes.co-lor eq red", expectedMessage: "invalid field at position 1"},
+	}
+	for _, testError := range testErrors {
+		t.Run("error if "+testError.filter, func(t *testing.T) {
+			resource := NewResource[string, string](definition)
+			err := resource.SetParam(Parameter{Limit: 10, Page: 1, Filters: []string{testError.filter}})
+			require.Error(t, err)
+			require.Equal(t, ValidationErrors{{FieldName: "filters.1", Errors: []string{testError.expectedMessage}}}, err)
+		})
+	}
+
+	t.Run("filter the typed fields", func(t *testing.T) {
+		resource := NewResource[string, string](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Filters: []string{
+			"quantity between 1 10",
+			"status eq published",
+			"attributes.size.unit in cm mm",
+			"created_on gte 2024-01-02",
+		}})
+		require.NoError(t, err)
+
+		resource.Select([]*Field{typedTable.Field("id")})
+		query, args, err := resource.QueryAndArgs()
+		require.NoError(t, err)
+		require.Contains(t, query, `item."quantity" BETWEEN $1 AND $2`)
+		require.Contains(t, query, `item."status" = $3`)
+		require.Contains(t, query, `(item."attributes" #>> '{size,unit}') in ($4,$5)`)
+		require.Contains(t, query, `item."created_on" >= $6`)
+		require.Equal(t, []any{"1", "10", "published", "cm", "mm", "2024-01-02"}, args)
+	})
+}
+
+func TestResource_SearchTypes(t *testing.T) {
+	newSearchDefinition := func(searchStrategy string) (*Table, *Definition) {
+		typedTable := newTypedTable()
+		for _, field := range []string{"id", "quantity", "status"} {
+			typedTable.Field(field).Searchable = true
+		}
+
+		definition, err := NewDefinition(typedTable, DefinitionOption{SearchStrategy: searchStrategy})
+		require.NoError(t, err)
+
+		return typedTable, definition
+	}
+
+	t.Run("like search on the text of uuid & enum", func(t *testing.T) {
+		_, definition := newSearchDefinition(LIKE_SEARCH)
+		resource := NewResource[string, string](definition)
+
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "publ"})
+		require.NoError(t, err)
+		require.Equal(t, []string{`(lower(item."id"::text) like $1 OR lower(item."name") like $2 OR lower(item."status"::text) like $3)`}, resource.whereStatements)
+		require.Equal(t, []any{"%publ%", "%publ%", "%publ%"}, resource.queryArgs)
+	})
+
+	t.Run("integer matched by equality only on the integer search", func(t *testing.T) {
+		_, definition := newSearchDefinition(LIKE_SEARCH)
+		resource := NewResource[string, string](definition)
+
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "12"})
+		require.NoError(t, err)
+		require.Equal(t, []string{`(item."quantity" = $1 OR lower(item."id"::text) like $2 OR lower(item."name") like $3 OR lower(item."status"::text) like $4)`}, resource.whereStatements)
+
+		err = resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "1.5"})
+		require.NoError(t, err)
+		require.NotContains(t, resource.whereStatements[0], `item."quantity"`)
+	})
+
+	t.Run("integer search term on the trigram & fulltext search", func(t *testing.T) {
+		for _, searchStrategy := range []string{TRIGRAM_SEARCH, FULLTEXT_SEARCH} {
+			_, definition := newSearchDefinition(searchStrategy)
+			resource := NewResource[string, string](definition)
+
+			err := resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "quantity:12"})
+			require.NoError(t, err)
+			require.Len(t, resource.whereStatements, 1)
+			require.Contains(t, resource.whereStatements[0], `item."quantity" = $1`, searchStrategy)
+			require.Equal(t, []any{"12"}, resource.queryArgs)
+
+			err = resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "quantity:1.5"})
+			require.Equal(t, ValidationErrors{{FieldName: "search", Errors: []string{"value must be a valid integer format at position 1"}}}, err)
+		}
+	})
+
+	t.Run("trigram search on the text of uuid & enum", func(t *testing.T) {
+		_, definition := newSearchDefinition(TRIGRAM_SEARCH)
+		resource := NewResource[string, string](definition)
+
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, Search: "status:publ"})
+		require.NoError(t, err)
+		require.Equal(t, []string{`(item."status"::text % $1)`}, resource.whereStatements)
+	})
+}
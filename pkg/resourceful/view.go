@@ -0,0 +1,103 @@
+package resourceful
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// View is the named filters & sorts preset applied by the Parameter view. The view filters are merged with
+// the Parameter filters & the view sorts are used only when there is no Parameter sorts
+type View struct {
+	Name    string   `json:"name"`
+	Filters []string `json:"filters"`
+	Sorts   []string `json:"sorts"`
+}
+
+var viewNameRegexp = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// Init the views registered on the DefinitionOption, the view filters & sorts are validated against the definition
+func (d *Definition) initViews(views []View) error {
+	d.viewsMap = make(map[string]View)
+
+	for _, view := range views {
+		if !viewNameRegexp.MatchString(view.Name) {
+			return createError(fmt.Sprintf(`invalid "%s" view name`, view.Name))
+		}
+		if _, ok := d.viewsMap[view.Name]; ok {
+			return createError(fmt.Sprintf(`duplicate "%s" view`, view.Name))
+		}
+
+		d.viewsMap[view.Name] = view
+		d.views = append(d.views, view)
+	}
+
+	for _, view := range d.views {
+		err := d.ValidateView(view)
+		if err != nil {
+			return createError(fmt.Sprintf(`invalid "%s" view: %s`, view.Name, err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// Return the views registered on the definition
+func (d *Definition) Views() []View {
+	return append([]View{}, d.views...)
+}
+
+// Check if the view name is registered on the definition
+func (d *Definition) HasView(name string) bool {
+	_, ok := d.viewsMap[name]
+	return ok
+}
+
+// Validate the view name, filters & sorts against the definition, the errors are returned as the ValidationErrors
+func (d *Definition) ValidateView(view View) error {
+	if !viewNameRegexp.MatchString(view.Name) {
+		var validationErrors ValidationErrors
+		validationErrors.appendFieldError("name", "must only contain lowercase letters, numbers, underscores or dashes")
+		return validationErrors
+	}
+
+	resource := NewResource[any, any](d)
+	resource.viewsMap[view.Name] = view
+
+	return resource.SetParam(Parameter{Limit: 1, Page: 1, View: view.Name})
+}
+
+// Add the view (e.g. the user saved view) to the Resource so it can be applied by the Parameter view,
+// the view is kept on SetParam. The view name can't override the definition view
+func (r *Resource[IDType, Model]) AddView(view View) error {
+	if r.isAPIResource {
+		return createError("view can't be used on the API resource")
+	}
+	if _, ok := r.viewsMap[view.Name]; ok {
+		return createError(fmt.Sprintf(`duplicate "%s" view`, view.Name))
+	}
+
+	r.viewsMap[view.Name] = view
+
+	return nil
+}
+
+// Check if the view name is registered on the definition or added to the Resource
+func (r *Resource[IDType, Model]) HasView(name string) bool {
+	_, ok := r.viewsMap[name]
+	return ok
+}
+
+func (r *Resource[IDType, Model]) processView(viewParam string) (*View, error) {
+	if viewParam == "" {
+		return nil, nil
+	}
+
+	view, ok := r.viewsMap[viewParam]
+	if !ok {
+		var validationErrors ValidationErrors
+		validationErrors.appendFieldError("view", "invalid view")
+		return nil, validationErrors
+	}
+
+	return &view, nil
+}
@@ -0,0 +1,100 @@
+package resourceful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefinition_Views(t *testing.T) {
+	t.Run("error if invalid view name", func(t *testing.T) {
+		_, err := NewDefinition(&productTable, DefinitionOption{Views: []View{{Name: "Expensive Products"}}})
+		require.Error(t, err)
+	})
+
+	t.Run("error if has duplicate view", func(t *testing.T) {
+		_, err := NewDefinition(&productTable, DefinitionOption{Views: []View{{Name: "expensive"}, {Name: "expensive"}}})
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid view filters", func(t *testing.T) {
+		_, err := NewDefinition(&productTable, DefinitionOption{Views: []View{{Name: "expensive", Filters: []string{"random_field eq 1"}}}})
+		require.Error(t, err)
+	})
+
+	t.Run("error if invalid view sorts", func(t *testing.T) {
+		_, err := NewDefinition(&productTable, DefinitionOption{Views: []View{{Name: "expensive", Sorts: []string{"count asc"}}}})
+		require.Error(t, err)
+	})
+
+	t.Run("no error if valid", func(t *testing.T) {
+		views := []View{{Name: "expensive", Filters: []string{"count gt 100"}, Sorts: []string{"name asc"}}}
+		definition, err := NewDefinition(&productTable, DefinitionOption{Views: views})
+		require.NoError(t, err)
+		require.Equal(t, views, definition.Views())
+		require.True(t, definition.HasView("expensive"))
+	})
+}
+
+func TestResource_View(t *testing.T) {
+	definition, err := NewDefinition(&productTable, DefinitionOption{Views: []View{
+		{Name: "expensive", Filters: []string{"count gt 100"}, Sorts: []string{"name asc"}},
+	}})
+	require.NoError(t, err)
+
+	t.Run("error if invalid view", func(t *testing.T) {
+		resource := NewResource[string, string](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, View: "random"})
+		require.Error(t, err)
+		require.Equal(t, "view", err.(ValidationErrors)[0].FieldName)
+	})
+
+	t.Run("merge the view filters & use the view sorts", func(t *testing.T) {
+		resource := NewResource[string, string](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, View: "expensive", Filters: []string{"name eq apple"}})
+		require.NoError(t, err)
+
+		resource.Select([]*Field{productTable.Field("id")})
+		query, args, err := resource.QueryAndArgs()
+		require.NoError(t, err)
+		require.Equal(t, `SELECT product."id" FROM product
+JOIN product_type pt ON product."product_type_id" = pt."id"
+WHERE product."name" = $1
+AND product."count" > $2
+AND product."is_deleted" is false
+ORDER BY product."name" ASC, product."created_on" DESC`, query)
+		require.Equal(t, []any{"apple", "100"}, args)
+	})
+
+	t.Run("sorts param override the view sorts", func(t *testing.T) {
+		resource := NewResource[string, string](definition)
+		err := resource.SetParam(Parameter{Limit: 10, Page: 1, View: "expensive", Sorts: []string{"created_on asc"}})
+		require.NoError(t, err)
+
+		resource.Select([]*Field{productTable.Field("id")})
+		query, _, err := resource.QueryAndArgs()
+		require.NoError(t, err)
+		require.Contains(t, query, `ORDER BY product."created_on" ASC`)
+		require.NotContains(t, query, `product."name" ASC`)
+	})
+
+	t.Run("apply the added view", func(t *testing.T) {
+		resource := NewResource[string, string](definition)
+		err := resource.AddView(View{Name: "mine", Filters: []string{"count eq a"}})
+		require.NoError(t, err)
+
+		err = resource.SetParam(Parameter{Limit: 10, Page: 1, View: "mine"})
+		require.Error(t, err)
+		require.Equal(t, "view.filters.1", err.(ValidationErrors)[0].FieldName)
+
+		// The added view is not shared with the other resource
+		err = NewResource[string, string](definition).SetParam(Parameter{Limit: 10, Page: 1, View: "mine"})
+		require.Error(t, err)
+	})
+
+	t.Run("error if added view override the definition view", func(t *testing.T) {
+		resource := NewResource[string, string](definition)
+		err := resource.AddView(View{Name: "expensive"})
+		require.Error(t, err)
+	})
+}